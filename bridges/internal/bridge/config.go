@@ -0,0 +1,49 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bridge holds the configuration shared by the log bridges.
+package bridge // import "github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridge"
+
+import (
+	otel "github.com/agoda-com/opentelemetry-logs-go"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+)
+
+// Config holds the settings of the Logger of a bridge.
+type Config struct {
+	// Provider is the LoggerProvider of the Logger, the global one if nil.
+	Provider logs.LoggerProvider
+	// Version is the instrumentation version of the Logger.
+	Version string
+	// SchemaURL is the schema URL of the Logger.
+	SchemaURL string
+}
+
+// Logger returns the logs.Logger for the instrumentation scope name.
+func (c Config) Logger(name string) logs.Logger {
+	provider := c.Provider
+	if provider == nil {
+		provider = otel.GetLoggerProvider()
+	}
+	var opts []logs.LoggerOption
+	if c.Version != "" {
+		opts = append(opts, logs.WithInstrumentationVersion(c.Version))
+	}
+	if c.SchemaURL != "" {
+		opts = append(opts, logs.WithSchemaURL(c.SchemaURL))
+	}
+	return provider.Logger(name, opts...)
+}
@@ -0,0 +1,30 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bridgetest provides the test fixtures shared by the log bridges.
+package bridgetest // import "github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridgetest"
+
+import (
+	sdk "github.com/agoda-com/opentelemetry-logs-go/sdk/logs"
+	"github.com/agoda-com/opentelemetry-logs-go/sdk/logs/logstest"
+)
+
+// NewLoggerProvider returns a LoggerProvider that exports every log record
+// to the returned InMemoryExporter as soon as it is emitted.
+func NewLoggerProvider() (*sdk.LoggerProvider, *logstest.InMemoryExporter) {
+	exporter := logstest.NewInMemoryExporter()
+	return sdk.NewLoggerProvider(sdk.WithSyncer(exporter)), exporter
+}
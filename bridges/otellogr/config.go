@@ -0,0 +1,96 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otellogr
+
+import (
+	"github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridge"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+)
+
+// NameMode defines how logger names added with logr.Logger.WithName are
+// recorded.
+type NameMode int
+
+const (
+	// NameAsAttribute records the logger name, joined with "/" when
+	// WithName is called several times, in the NameKey attribute.
+	NameAsAttribute NameMode = iota
+	// NameAsScope emits the log records through a Logger whose
+	// instrumentation scope name is the LogSink scope name followed by the
+	// logger names, all joined with "/".
+	NameAsScope
+)
+
+// config contains options for the logr LogSink.
+type config struct {
+	bridge.Config
+	nameMode NameMode
+}
+
+// newConfig creates a config configured with options.
+func newConfig(options ...Option) config {
+	var cfg config
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option configures a LogSink.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithLoggerProvider sets the LoggerProvider used to create the Logger of the
+// LogSink. By default, the global LoggerProvider is used.
+func WithLoggerProvider(provider logs.LoggerProvider) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.Provider = provider
+		return cfg
+	})
+}
+
+// WithVersion sets the instrumentation version of the Logger.
+func WithVersion(version string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.Version = version
+		return cfg
+	})
+}
+
+// WithSchemaURL sets the schema URL of the Logger.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.SchemaURL = schemaURL
+		return cfg
+	})
+}
+
+// WithNameMode sets how logger names added with logr.Logger.WithName are
+// recorded. The default is NameAsAttribute.
+func WithNameMode(mode NameMode) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.nameMode = mode
+		return cfg
+	})
+}
@@ -0,0 +1,30 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package otellogr provides a logr.LogSink that bridges go-logr calls into
+OpenTelemetry log records.
+
+	logger := otellogr.NewLogger("my/package")
+	logger.V(1).Info("reconciling", "object", key, "context", ctx)
+
+Verbosity levels are mapped onto severity numbers: level 0 becomes INFO,
+level 1 DEBUG4 and every further level one step lower, down to TRACE.
+Error calls become ERROR records with exception.* attributes. The trace
+context is taken from a value holding a context.Context passed to the log
+call or to WithValues.
+*/
+package otellogr // import "github.com/agoda-com/opentelemetry-logs-go/bridges/otellogr"
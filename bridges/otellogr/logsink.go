@@ -0,0 +1,253 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otellogr
+
+import (
+	"context"
+	"fmt"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"math"
+	"time"
+)
+
+// NameKey is the attribute key of the logger name in NameAsAttribute mode.
+const NameKey = attribute.Key("logger.name")
+
+// badKey is the key used for a value that has no key.
+const badKey = "!BADKEY"
+
+// NewLogger returns a logr.Logger backed by a LogSink for the
+// instrumentation scope name.
+func NewLogger(name string, options ...Option) logr.Logger {
+	return logr.New(NewLogSink(name, options...))
+}
+
+// LogSink is a logr.LogSink that emits logr calls as OpenTelemetry log
+// records through a logs.Logger.
+type LogSink struct {
+	logger logs.Logger
+	cfg    config
+	// scope is the instrumentation scope name of logger.
+	scope string
+	// name is the logger name in NameAsAttribute mode.
+	name  string
+	attrs []attribute.KeyValue
+	// ctx is the context passed as a value to WithValues, if any.
+	ctx context.Context
+}
+
+var _ logr.LogSink = (*LogSink)(nil)
+
+// NewLogSink returns a new LogSink that emits log records through a Logger
+// for the instrumentation scope name.
+func NewLogSink(name string, options ...Option) *LogSink {
+	cfg := newConfig(options...)
+	return &LogSink{
+		logger: cfg.Logger(name),
+		cfg:    cfg,
+		scope:  name,
+		ctx:    context.Background(),
+	}
+}
+
+// Init does nothing, the LogSink does not record the call site.
+func (s *LogSink) Init(logr.RuntimeInfo) {}
+
+// Enabled reports whether the Logger of the LogSink processes records at the
+// verbosity level.
+func (s *LogSink) Enabled(level int) bool {
+	return s.logger.Enabled(s.ctx, logs.EnabledParameters{Severity: convertLevel(level)})
+}
+
+// Info emits a log record with the severity of the verbosity level and a
+// severity text such as "V(1)".
+func (s *LogSink) Info(level int, msg string, keysAndValues ...any) {
+	s.emit(convertLevel(level), levelText(level), msg, nil, keysAndValues)
+}
+
+// Error emits a log record with ERROR severity and severity text. The error
+// is recorded in the exception.message and exception.type attributes.
+func (s *LogSink) Error(err error, msg string, keysAndValues ...any) {
+	var attrs []attribute.KeyValue
+	if err != nil {
+		attrs = []attribute.KeyValue{
+			semconv.ExceptionMessage(err.Error()),
+			semconv.ExceptionType(fmt.Sprintf("%T", err)),
+		}
+	}
+	s.emit(logs.ERROR, logs.ERROR.Name(), msg, attrs, keysAndValues)
+}
+
+func (s *LogSink) emit(severity logs.SeverityNumber, severityText string, msg string, extra []attribute.KeyValue, keysAndValues []any) {
+	ctx := s.ctx
+	attrs := make([]attribute.KeyValue, 0, len(s.attrs)+len(keysAndValues)/2+len(extra)+1)
+	if s.name != "" {
+		attrs = append(attrs, NameKey.String(s.name))
+	}
+	attrs = append(attrs, s.attrs...)
+	attrs, ctx = appendKeysAndValues(attrs, ctx, keysAndValues)
+	attrs = append(attrs, extra...)
+
+	now := time.Now()
+	lrc := logs.LogRecordConfig{
+		Timestamp:         &now,
+		ObservedTimestamp: now,
+		SeverityText:      &severityText,
+		SeverityNumber:    &severity,
+		BodyValue:         logs.StringValue(msg),
+		Attributes:        &attrs,
+	}
+	s.logger.EmitContext(ctx, logs.NewLogRecord(lrc))
+}
+
+// WithValues returns a LogSink that adds keysAndValues to every record.
+//
+// A value holding a context.Context is not recorded as an attribute, its
+// trace context is used for the log records instead.
+func (s *LogSink) WithValues(keysAndValues ...any) logr.LogSink {
+	s2 := *s
+	s2.attrs = make([]attribute.KeyValue, len(s.attrs), len(s.attrs)+len(keysAndValues)/2)
+	copy(s2.attrs, s.attrs)
+	s2.attrs, s2.ctx = appendKeysAndValues(s2.attrs, s.ctx, keysAndValues)
+	return &s2
+}
+
+// WithName returns a LogSink with name appended to its logger name.
+func (s *LogSink) WithName(name string) logr.LogSink {
+	s2 := *s
+	if s.cfg.nameMode == NameAsScope {
+		s2.scope = s.scope + "/" + name
+		s2.logger = s.cfg.Logger(s2.scope)
+		return &s2
+	}
+	if s.name == "" {
+		s2.name = name
+	} else {
+		s2.name = s.name + "/" + name
+	}
+	return &s2
+}
+
+// convertLevel maps a logr verbosity level onto a logs.SeverityNumber. Level
+// 0 is INFO and every level above lowers the severity by one step, from
+// DEBUG4 at level 1 down to TRACE at level 8 and above.
+func convertLevel(level int) logs.SeverityNumber {
+	if level <= 0 {
+		return logs.INFO
+	}
+	if level >= int(logs.INFO-logs.TRACE) {
+		return logs.TRACE
+	}
+	return logs.INFO - logs.SeverityNumber(level)
+}
+
+// levelText returns the severity text of a logr verbosity level.
+func levelText(level int) string {
+	if level < 0 {
+		level = 0
+	}
+	return fmt.Sprintf("V(%d)", level)
+}
+
+// appendKeysAndValues appends the logr key/value pairs to attrs. A value
+// holding a context.Context replaces ctx instead.
+func appendKeysAndValues(attrs []attribute.KeyValue, ctx context.Context, keysAndValues []any) ([]attribute.KeyValue, context.Context) {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			attrs = append(attrs, attribute.KeyValue{Key: badKey, Value: value(keysAndValues[i])})
+			break
+		}
+		if c, ok := keysAndValues[i+1].(context.Context); ok {
+			ctx = c
+			continue
+		}
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		attrs = append(attrs, attribute.KeyValue{Key: attribute.Key(key), Value: value(keysAndValues[i+1])})
+	}
+	return attrs, ctx
+}
+
+func value(v any) attribute.Value {
+	if m, ok := v.(logr.Marshaler); ok {
+		v = m.MarshalLog()
+	}
+	switch val := v.(type) {
+	case nil:
+		return attribute.StringValue("<nil>")
+	case string:
+		return attribute.StringValue(val)
+	case bool:
+		return attribute.BoolValue(val)
+	case int:
+		return attribute.IntValue(val)
+	case int64:
+		return attribute.Int64Value(val)
+	case int32:
+		return attribute.Int64Value(int64(val))
+	case int16:
+		return attribute.Int64Value(int64(val))
+	case int8:
+		return attribute.Int64Value(int64(val))
+	case uint:
+		return uintValue(uint64(val))
+	case uint64:
+		return uintValue(val)
+	case uint32:
+		return attribute.Int64Value(int64(val))
+	case uint16:
+		return attribute.Int64Value(int64(val))
+	case uint8:
+		return attribute.Int64Value(int64(val))
+	case float64:
+		return attribute.Float64Value(val)
+	case float32:
+		return attribute.Float64Value(float64(val))
+	case time.Duration:
+		return attribute.Int64Value(val.Nanoseconds())
+	case time.Time:
+		return attribute.StringValue(val.Format(time.RFC3339Nano))
+	case []string:
+		return attribute.StringSliceValue(val)
+	case []int:
+		return attribute.IntSliceValue(val)
+	case []int64:
+		return attribute.Int64SliceValue(val)
+	case []float64:
+		return attribute.Float64SliceValue(val)
+	case []bool:
+		return attribute.BoolSliceValue(val)
+	case error:
+		return attribute.StringValue(val.Error())
+	case fmt.Stringer:
+		return attribute.StringValue(val.String())
+	default:
+		return attribute.StringValue(fmt.Sprintf("%+v", val))
+	}
+}
+
+func uintValue(v uint64) attribute.Value {
+	if v > math.MaxInt64 {
+		v = math.MaxInt64
+	}
+	return attribute.Int64Value(int64(v))
+}
@@ -0,0 +1,145 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otellogr
+
+import (
+	"context"
+	"errors"
+	"github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridgetest"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	sdk "github.com/agoda-com/opentelemetry-logs-go/sdk/logs"
+	"github.com/agoda-com/opentelemetry-logs-go/sdk/logs/logstest"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func newTestLogger(options ...Option) (logr.Logger, *logstest.InMemoryExporter) {
+	provider, exporter := bridgetest.NewLoggerProvider()
+	options = append([]Option{WithLoggerProvider(provider)}, options...)
+	return NewLogger("test", options...), exporter
+}
+
+func TestLogSinkInfo(t *testing.T) {
+	logger, exporter := newTestLogger()
+
+	logger.WithValues("service", "api").Info("hello", "count", 3, "ok", true)
+	logger.V(2).Info("verbose", "odd")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "hello", records[0].Body)
+	assert.Equal(t, logs.INFO, *records[0].SeverityNumber)
+	assert.Equal(t, "V(0)", *records[0].SeverityText)
+	assert.Equal(t, "test", records[0].InstrumentationScope.Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("service", "api"),
+		attribute.Int("count", 3),
+		attribute.Bool("ok", true),
+	}, *records[0].Attributes)
+
+	assert.Equal(t, logs.DEBUG3, *records[1].SeverityNumber)
+	assert.Equal(t, "V(2)", *records[1].SeverityText)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("!BADKEY", "odd")}, *records[1].Attributes)
+}
+
+func TestLogSinkError(t *testing.T) {
+	logger, exporter := newTestLogger()
+
+	logger.Error(errors.New("boom"), "failed", "id", 1)
+	logger.Error(nil, "no error")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 2)
+	assert.Equal(t, "failed", records[0].Body)
+	assert.Equal(t, logs.ERROR, *records[0].SeverityNumber)
+	assert.Equal(t, "ERROR", *records[0].SeverityText)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int("id", 1),
+		semconv.ExceptionMessage("boom"),
+		semconv.ExceptionType("*errors.errorString"),
+	}, *records[0].Attributes)
+	assert.Empty(t, *records[1].Attributes)
+}
+
+func TestLogSinkWithName(t *testing.T) {
+	logger, exporter := newTestLogger()
+	logger.WithName("controller").WithName("pod").Info("named")
+
+	scoped, scopedExporter := newTestLogger(WithNameMode(NameAsScope))
+	scoped.WithName("controller").WithName("pod").Info("scoped")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, "test", records[0].InstrumentationScope.Name)
+	assert.Equal(t, []attribute.KeyValue{NameKey.String("controller/pod")}, *records[0].Attributes)
+
+	records = scopedExporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, "test/controller/pod", records[0].InstrumentationScope.Name)
+	assert.Empty(t, *records[0].Attributes)
+}
+
+func TestLogSinkTraceContext(t *testing.T) {
+	logger, exporter := newTestLogger()
+
+	traceID, _ := trace.TraceIDFromHex("80f198ee56343ba864fe8b2a57d3eff7")
+	spanID, _ := trace.SpanIDFromHex("2a00000000000000")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	logger.WithValues("context", ctx).Info("with values")
+	logger.Info("call", "context", ctx)
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 2)
+	for _, record := range records {
+		assert.Equal(t, traceID, *record.TraceId)
+		assert.Equal(t, spanID, *record.SpanId)
+		assert.Empty(t, *record.Attributes)
+	}
+}
+
+func TestLogSinkEnabled(t *testing.T) {
+	logger := NewLogger("test", WithLoggerProvider(sdk.NewLoggerProvider()))
+	assert.False(t, logger.Enabled())
+
+	logger, _ = newTestLogger()
+	assert.True(t, logger.V(10).Enabled())
+}
+
+func TestConvertLevel(t *testing.T) {
+	for level, severity := range map[int]logs.SeverityNumber{
+		-1: logs.INFO,
+		0:  logs.INFO,
+		1:  logs.DEBUG4,
+		4:  logs.DEBUG,
+		5:  logs.TRACE4,
+		7:  logs.TRACE2,
+		8:  logs.TRACE,
+		20: logs.TRACE,
+	} {
+		assert.Equal(t, severity, convertLevel(level), level)
+	}
+}
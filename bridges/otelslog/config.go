@@ -0,0 +1,111 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelslog
+
+import (
+	"github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridge"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+)
+
+// GroupMode defines how attributes inside slog groups are recorded.
+type GroupMode int
+
+const (
+	// GroupsAsStructuredAttributes records each outermost group as a map
+	// structured attribute holding the attributes inside it, with nested
+	// groups as nested maps.
+	GroupsAsStructuredAttributes GroupMode = iota
+	// GroupsAsDottedKeys records attributes inside groups as log record
+	// attributes whose keys are prefixed with the group names joined by dots,
+	// e.g. "request.method".
+	GroupsAsDottedKeys
+	// GroupsAsNestedBody records attributes inside groups as nested maps in
+	// the log record body. The message is then stored under the "message"
+	// key of the body. Attributes outside any group stay log record
+	// attributes.
+	GroupsAsNestedBody
+)
+
+// config contains options for the slog Handler.
+type config struct {
+	bridge.Config
+	source    bool
+	groupMode GroupMode
+}
+
+// newConfig creates a config configured with options.
+func newConfig(options ...Option) config {
+	var cfg config
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option configures a Handler.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithLoggerProvider sets the LoggerProvider used to create the Logger of the
+// Handler. By default, the global LoggerProvider is used.
+func WithLoggerProvider(provider logs.LoggerProvider) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.Provider = provider
+		return cfg
+	})
+}
+
+// WithVersion sets the instrumentation version of the Logger.
+func WithVersion(version string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.Version = version
+		return cfg
+	})
+}
+
+// WithSchemaURL sets the schema URL of the Logger.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.SchemaURL = schemaURL
+		return cfg
+	})
+}
+
+// WithSource configures the Handler to record the source code location of
+// the log call as code.filepath, code.lineno and code.function attributes.
+func WithSource(source bool) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.source = source
+		return cfg
+	})
+}
+
+// WithGroupMode sets how attributes inside slog groups are recorded. The
+// default is GroupsAsStructuredAttributes.
+func WithGroupMode(mode GroupMode) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.groupMode = mode
+		return cfg
+	})
+}
@@ -0,0 +1,33 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package otelslog provides a log/slog Handler that bridges slog records into
+OpenTelemetry log records.
+
+	logger := otelslog.NewLogger("my/package", otelslog.WithSource(true))
+	logger.InfoContext(ctx, "hello", "user", "alice")
+
+slog levels are mapped onto severity numbers keeping their offsets, so
+slog.LevelDebug becomes DEBUG, slog.LevelInfo INFO, slog.LevelWarn WARN and
+slog.LevelError ERROR. The trace context is taken from the context passed to
+the slog call.
+
+Attributes are recorded as log record attributes. Groups, maps, structs and
+other values without an attribute type are recorded as structured attributes,
+see WithGroupMode.
+*/
+package otelslog // import "github.com/agoda-com/opentelemetry-logs-go/bridges/otelslog"
@@ -0,0 +1,302 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelslog
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"log/slog"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bodyMessageKey is the body key of the message in GroupsAsNestedBody mode.
+const bodyMessageKey = "message"
+
+// NewLogger returns a *slog.Logger backed by a Handler for the
+// instrumentation scope name.
+func NewLogger(name string, options ...Option) *slog.Logger {
+	return slog.New(NewHandler(name, options...))
+}
+
+// Handler is a slog.Handler that emits slog records as OpenTelemetry log
+// records through a logs.Logger.
+type Handler struct {
+	logger logs.Logger
+	cfg    config
+
+	// attrs are the attributes added with WithAttrs, each with the groups
+	// that were open when they were added.
+	attrs  []groupedAttrs
+	groups []string
+}
+
+type groupedAttrs struct {
+	groups []string
+	attrs  []slog.Attr
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// NewHandler returns a new Handler that emits log records through a Logger
+// for the instrumentation scope name.
+func NewHandler(name string, options ...Option) *Handler {
+	cfg := newConfig(options...)
+	return &Handler{
+		logger: cfg.Logger(name),
+		cfg:    cfg,
+	}
+}
+
+// Enabled reports whether the Logger of the Handler processes records at the
+// given level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.Enabled(ctx, logs.EnabledParameters{Severity: logs.SeverityFromSlog(level)})
+}
+
+// Handle emits record as a log record. The trace context is taken from ctx.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	b := recordBuilder{
+		mode:  h.cfg.groupMode,
+		attrs: make([]attribute.KeyValue, 0, record.NumAttrs()),
+	}
+	for _, ga := range h.attrs {
+		for _, a := range ga.attrs {
+			b.add(ga.groups, a)
+		}
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		b.add(h.groups, a)
+		return true
+	})
+	if h.cfg.source && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		b.attrs = append(b.attrs,
+			semconv.CodeFilepath(frame.File),
+			semconv.CodeLineNumber(frame.Line),
+			semconv.CodeFunction(frame.Function),
+		)
+	}
+
+	severity := logs.SeverityFromSlog(record.Level)
+	severityText := record.Level.String()
+	for _, name := range sortedKeys(b.groups) {
+		b.structured = append(b.structured, logs.KeyValue{Key: name, Value: mapValue(b.groups[name].(map[string]any))})
+	}
+	lrc := logs.LogRecordConfig{
+		ObservedTimestamp:    time.Now(),
+		SeverityText:         &severityText,
+		SeverityNumber:       &severity,
+		BodyValue:            logs.StringValue(record.Message),
+		Attributes:           &b.attrs,
+		StructuredAttributes: b.structured,
+	}
+	if !record.Time.IsZero() {
+		lrc.Timestamp = &record.Time
+	}
+	if b.body != nil {
+		b.body[bodyMessageKey] = logs.StringValue(record.Message)
+		lrc.BodyValue = mapValue(b.body)
+	}
+
+	h.logger.EmitContext(ctx, logs.NewLogRecord(lrc))
+	return nil
+}
+
+// WithAttrs returns a Handler that adds attrs to every record it handles.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = append(h.attrs[:len(h.attrs):len(h.attrs)], groupedAttrs{groups: h.groups, attrs: attrs})
+	return &h2
+}
+
+// WithGroup returns a Handler that records all subsequent attributes inside
+// the group name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &h2
+}
+
+// recordBuilder collects the attributes and body of a log record.
+type recordBuilder struct {
+	mode       GroupMode
+	attrs      []attribute.KeyValue
+	structured []logs.KeyValue
+	// groups holds the groups recorded as structured attributes, and body
+	// the groups recorded in the body, as nested maps with the attribute
+	// values as logs.Values.
+	groups map[string]any
+	body   map[string]any
+}
+
+func (b *recordBuilder) add(groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		groupAttrs := a.Value.Group()
+		if len(groupAttrs) == 0 {
+			return
+		}
+		// Groups with an empty key are inlined.
+		if a.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], a.Key)
+		}
+		for _, ga := range groupAttrs {
+			b.add(groups, ga)
+		}
+		return
+	}
+
+	switch {
+	case len(groups) == 0 || b.mode == GroupsAsDottedKeys:
+		key := a.Key
+		if len(groups) > 0 {
+			key = strings.Join(groups, ".") + "." + a.Key
+		}
+		if v, ok := attributeValue(a.Value); ok {
+			b.attrs = append(b.attrs, attribute.KeyValue{Key: attribute.Key(key), Value: v})
+		} else if v := bodyValue(a.Value); !v.Empty() {
+			b.structured = append(b.structured, logs.KeyValue{Key: key, Value: v})
+		}
+	case b.mode == GroupsAsNestedBody:
+		group(&b.body, groups)[a.Key] = bodyValue(a.Value)
+	default:
+		group(&b.groups, groups)[a.Key] = bodyValue(a.Value)
+	}
+}
+
+// group returns the map for groups in *root, creating it when missing.
+func group(root *map[string]any, groups []string) map[string]any {
+	if *root == nil {
+		*root = make(map[string]any)
+	}
+	m := *root
+	for _, g := range groups {
+		child, ok := m[g].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			m[g] = child
+		}
+		m = child
+	}
+	return m
+}
+
+// attributeValue returns the attribute value of v, false if it has no
+// attribute representation and is recorded as a structured attribute.
+func attributeValue(v slog.Value) (attribute.Value, bool) {
+	switch v.Kind() {
+	case slog.KindString:
+		return attribute.StringValue(v.String()), true
+	case slog.KindInt64:
+		return attribute.Int64Value(v.Int64()), true
+	case slog.KindUint64:
+		u := v.Uint64()
+		if u > math.MaxInt64 {
+			u = math.MaxInt64
+		}
+		return attribute.Int64Value(int64(u)), true
+	case slog.KindFloat64:
+		return attribute.Float64Value(v.Float64()), true
+	case slog.KindBool:
+		return attribute.BoolValue(v.Bool()), true
+	case slog.KindDuration:
+		return attribute.Int64Value(v.Duration().Nanoseconds()), true
+	case slog.KindTime:
+		return attribute.StringValue(v.Time().Format(time.RFC3339Nano)), true
+	}
+
+	switch val := v.Any().(type) {
+	case error:
+		return attribute.StringValue(val.Error()), true
+	case []string:
+		return attribute.StringSliceValue(val), true
+	case []int:
+		return attribute.IntSliceValue(val), true
+	case []int64:
+		return attribute.Int64SliceValue(val), true
+	case []float64:
+		return attribute.Float64SliceValue(val), true
+	case []bool:
+		return attribute.BoolSliceValue(val), true
+	}
+	return attribute.Value{}, false
+}
+
+func bodyValue(v slog.Value) logs.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return logs.StringValue(v.String())
+	case slog.KindInt64:
+		return logs.Int64Value(v.Int64())
+	case slog.KindUint64:
+		u := v.Uint64()
+		if u > math.MaxInt64 {
+			u = math.MaxInt64
+		}
+		return logs.Int64Value(int64(u))
+	case slog.KindFloat64:
+		return logs.Float64Value(v.Float64())
+	case slog.KindBool:
+		return logs.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return logs.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindTime:
+		return logs.StringValue(v.Time().Format(time.RFC3339Nano))
+	}
+	return logs.ValueOf(v.Any())
+}
+
+// mapValue converts a map built by recordBuilder into a map Value sorted by
+// key.
+func mapValue(m map[string]any) logs.Value {
+	keys := sortedKeys(m)
+	kvs := make([]logs.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case map[string]any:
+			kvs = append(kvs, logs.KeyValue{Key: k, Value: mapValue(v)})
+		case logs.Value:
+			kvs = append(kvs, logs.KeyValue{Key: k, Value: v})
+		}
+	}
+	return logs.MapValue(kvs...)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
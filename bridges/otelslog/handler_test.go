@@ -0,0 +1,202 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelslog
+
+import (
+	"context"
+	"errors"
+	"github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridgetest"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	sdk "github.com/agoda-com/opentelemetry-logs-go/sdk/logs"
+	"github.com/agoda-com/opentelemetry-logs-go/sdk/logs/logstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestLogger(options ...Option) (*slog.Logger, *logstest.InMemoryExporter) {
+	provider, exporter := bridgetest.NewLoggerProvider()
+	options = append([]Option{WithLoggerProvider(provider), WithVersion("v0.1.0")}, options...)
+	return NewLogger("test", options...), exporter
+}
+
+func TestHandler(t *testing.T) {
+	logger, exporter := newTestLogger()
+
+	logger.Warn("hello", "user", "alice", "count", 3, "err", errors.New("boom"), "ratio", 0.5, "ok", true)
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	record := records[0]
+	assert.Equal(t, "hello", record.Body)
+	assert.Equal(t, logs.WARN, *record.SeverityNumber)
+	assert.Equal(t, "WARN", *record.SeverityText)
+	assert.NotNil(t, record.Timestamp)
+	assert.Equal(t, "test", record.InstrumentationScope.Name)
+	assert.Equal(t, "v0.1.0", record.InstrumentationScope.Version)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("user", "alice"),
+		attribute.Int64("count", 3),
+		attribute.String("err", "boom"),
+		attribute.Float64("ratio", 0.5),
+		attribute.Bool("ok", true),
+	}, *record.Attributes)
+}
+
+func TestHandlerStructuredGroups(t *testing.T) {
+	logger, exporter := newTestLogger()
+
+	logger.With("service", "api").
+		WithGroup("request").
+		With("method", "GET").
+		Info("done", slog.Group("response", "status", 200), slog.Group("", "inline", 1), slog.Group("empty"))
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, "done", records[0].Body)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("service", "api")}, *records[0].Attributes)
+	assert.Equal(t, []logs.KeyValue{
+		logs.Map("request",
+			logs.Int64("inline", 1),
+			logs.String("method", "GET"),
+			logs.Map("response", logs.Int64("status", 200)),
+		),
+	}, records[0].StructuredAttributes)
+}
+
+func TestHandlerStructuredValues(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	logger, exporter := newTestLogger()
+
+	logger.Info("values",
+		"point", point{X: 1, Y: 2},
+		"labels", map[string]string{"b": "2", "a": "1"},
+		"mixed", []any{"a", 1},
+		"tags", []string{"x"},
+		"nil", nil,
+	)
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.StringSlice("tags", []string{"x"})}, *records[0].Attributes)
+	assert.Equal(t, []logs.KeyValue{
+		logs.Map("point", logs.Int64("X", 1), logs.Int64("Y", 2)),
+		logs.Map("labels", logs.String("a", "1"), logs.String("b", "2")),
+		logs.Slice("mixed", logs.StringValue("a"), logs.Int64Value(1)),
+	}, records[0].StructuredAttributes)
+}
+
+func TestHandlerDottedGroups(t *testing.T) {
+	logger, exporter := newTestLogger(WithGroupMode(GroupsAsDottedKeys))
+
+	logger.With("service", "api").
+		WithGroup("request").
+		With("method", "GET").
+		Info("done", slog.Group("response", "status", 200), slog.Group("", "inline", 1), slog.Group("empty"))
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, "done", records[0].Body)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("service", "api"),
+		attribute.String("request.method", "GET"),
+		attribute.Int64("request.response.status", 200),
+		attribute.Int64("request.inline", 1),
+	}, *records[0].Attributes)
+}
+
+func TestHandlerNestedBodyGroups(t *testing.T) {
+	logger, exporter := newTestLogger(WithGroupMode(GroupsAsNestedBody))
+
+	logger.With("service", "api").
+		WithGroup("request").
+		With("method", "GET").
+		Info("done", slog.Group("response", "status", 200))
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, map[string]any{
+		"message": "done",
+		"request": map[string]any{
+			"method": "GET",
+			"response": map[string]any{
+				"status": int64(200),
+			},
+		},
+	}, records[0].Body)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("service", "api")}, *records[0].Attributes)
+}
+
+func TestHandlerTraceContext(t *testing.T) {
+	logger, exporter := newTestLogger()
+
+	traceID, _ := trace.TraceIDFromHex("80f198ee56343ba864fe8b2a57d3eff7")
+	spanID, _ := trace.SpanIDFromHex("2a00000000000000")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	logger.InfoContext(ctx, "traced")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, traceID, *records[0].TraceId)
+	assert.Equal(t, spanID, *records[0].SpanId)
+	assert.Equal(t, trace.FlagsSampled, *records[0].TraceFlags)
+}
+
+func TestHandlerSource(t *testing.T) {
+	logger, exporter := newTestLogger(WithSource(true))
+
+	logger.Info("with source")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	attrs := attribute.NewSet(*records[0].Attributes...)
+	file, ok := attrs.Value("code.filepath")
+	assert.True(t, ok)
+	assert.Contains(t, file.AsString(), "handler_test.go")
+	function, ok := attrs.Value("code.function")
+	assert.True(t, ok)
+	assert.Contains(t, function.AsString(), "TestHandlerSource")
+	_, ok = attrs.Value("code.lineno")
+	assert.True(t, ok)
+}
+
+func TestHandlerEnabled(t *testing.T) {
+	handler := NewHandler("test", WithLoggerProvider(sdk.NewLoggerProvider()))
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelError))
+
+	logger, exporter := newTestLogger()
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelDebug))
+	logger.Debug("debug", "at", time.Unix(0, 0).UTC(), "took", time.Second)
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("at", "1970-01-01T00:00:00Z"),
+		attribute.Int64("took", int64(time.Second)),
+	}, *records[0].Attributes)
+}
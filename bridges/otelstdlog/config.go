@@ -0,0 +1,126 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelstdlog
+
+import (
+	"github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridge"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+)
+
+// config contains options for the Writer.
+type config struct {
+	bridge.Config
+
+	severity       logs.SeverityNumber
+	detectSeverity bool
+	splitLines     bool
+
+	// prefix and flag are the settings of the log.Logger writing to the
+	// Writer.
+	prefix string
+	flag   int
+}
+
+// newConfig creates a config configured with options.
+func newConfig(options ...Option) config {
+	cfg := config{severity: logs.INFO}
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option configures a Writer.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithLoggerProvider sets the LoggerProvider used to create the Logger of the
+// Writer. By default, the global LoggerProvider is used.
+func WithLoggerProvider(provider logs.LoggerProvider) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.Provider = provider
+		return cfg
+	})
+}
+
+// WithVersion sets the instrumentation version of the Logger.
+func WithVersion(version string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.Version = version
+		return cfg
+	})
+}
+
+// WithSchemaURL sets the schema URL of the Logger.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.SchemaURL = schemaURL
+		return cfg
+	})
+}
+
+// WithSeverity sets the severity of the log records. The default is INFO.
+func WithSeverity(severity logs.SeverityNumber) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.severity = severity
+		return cfg
+	})
+}
+
+// WithSeverityDetection configures the Writer to take the severity of a
+// message from its first word, such as "ERROR", "[warn]" or "debug:", as
+// parsed by logs.ParseSeverity. Messages without a known severity get the
+// severity set with WithSeverity.
+func WithSeverityDetection(detect bool) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.detectSeverity = detect
+		return cfg
+	})
+}
+
+// WithSplitLines configures the Writer to emit every line as a separate log
+// record. By default, a write that ends with a newline emits everything
+// buffered as one log record, so multi-line messages of a log.Logger are kept
+// together.
+func WithSplitLines(split bool) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.splitLines = split
+		return cfg
+	})
+}
+
+// WithLogFlags sets the prefix and flags of the log.Logger writing to the
+// Writer. The header the log.Logger adds to every message is then parsed:
+// the date and time become the timestamp of the log record, the file and line
+// become code.filepath and code.lineno attributes and the prefix becomes the
+// PrefixKey attribute.
+//
+// NewLogger sets these automatically.
+func WithLogFlags(prefix string, flag int) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.prefix = prefix
+		cfg.flag = flag
+		return cfg
+	})
+}
@@ -0,0 +1,32 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package otelstdlog provides an io.Writer and a *log.Logger factory that
+bridge the standard library log package and plain writers into OpenTelemetry
+log records.
+
+	logger := otelstdlog.NewLogger("my/package", "app: ", log.LstdFlags|log.Lshortfile)
+	logger.Printf("hello %s", "alice")
+
+	// Third-party code writing through the standard logger.
+	log.SetOutput(otelstdlog.NewWriter("thirdparty", otelstdlog.WithSeverityDetection(true)))
+
+Every message is emitted with a fixed severity, INFO unless changed with
+WithSeverity, or with the severity named by its first word when
+WithSeverityDetection is enabled.
+*/
+package otelstdlog // import "github.com/agoda-com/opentelemetry-logs-go/bridges/otelstdlog"
@@ -0,0 +1,223 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelstdlog
+
+import (
+	"bytes"
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PrefixKey is the attribute key of the log.Logger prefix.
+const PrefixKey = attribute.Key("log.prefix")
+
+// maxBufferSize is the size above which a message without a trailing newline
+// is emitted anyway.
+const maxBufferSize = 64 * 1024
+
+// NewLogger returns a *log.Logger with the given prefix and flags that writes
+// to a Writer for the instrumentation scope name.
+func NewLogger(name string, prefix string, flag int, options ...Option) *log.Logger {
+	options = append(options, WithLogFlags(prefix, flag))
+	return log.New(NewWriter(name, options...), prefix, flag)
+}
+
+// Writer is an io.Writer that emits what is written to it as OpenTelemetry
+// log records through a logs.Logger.
+//
+// Data is buffered until a write ends with a newline, so partial writes are
+// joined into one log record and the lines of a multi-line message written at
+// once stay together. When a write ends within a line, the complete lines
+// before it are emitted one by one and only the partial line stays buffered.
+// Call Flush to emit a buffered message that was not terminated by a newline.
+type Writer struct {
+	logger logs.Logger
+	cfg    config
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+var _ io.Writer = (*Writer)(nil)
+
+// NewWriter returns a new Writer that emits log records through a Logger for
+// the instrumentation scope name.
+func NewWriter(name string, options ...Option) *Writer {
+	cfg := newConfig(options...)
+	return &Writer{
+		logger: cfg.Logger(name),
+		cfg:    cfg,
+	}
+}
+
+// Write buffers p and emits the complete messages it holds. It always
+// returns len(p) and a nil error.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	if !w.cfg.splitLines && len(w.buf) > 0 && w.buf[len(w.buf)-1] == '\n' {
+		w.emit(string(w.buf[:len(w.buf)-1]))
+		w.buf = w.buf[:0]
+	} else {
+		// The complete lines are emitted one by one, only the partial line
+		// at the end stays buffered.
+		for {
+			i := bytes.IndexByte(w.buf, '\n')
+			if i < 0 {
+				break
+			}
+			w.emit(string(w.buf[:i]))
+			w.buf = w.buf[i+1:]
+		}
+	}
+
+	if len(w.buf) > maxBufferSize {
+		w.emit(string(w.buf))
+		w.buf = w.buf[:0]
+	}
+	if len(w.buf) == 0 {
+		// Release the memory of large messages.
+		w.buf = nil
+	}
+	return len(p), nil
+}
+
+// Flush emits the buffered message that was not terminated by a newline.
+func (w *Writer) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.emit(string(w.buf))
+		w.buf = nil
+	}
+}
+
+func (w *Writer) emit(msg string) {
+	msg = strings.TrimSuffix(msg, "\r")
+	if msg == "" {
+		return
+	}
+
+	now := time.Now()
+	lrc := logs.LogRecordConfig{
+		Timestamp:         &now,
+		ObservedTimestamp: now,
+	}
+
+	var attrs []attribute.KeyValue
+	msg, attrs, lrc.Timestamp = w.parseHeader(msg, attrs, lrc.Timestamp)
+
+	severity := w.cfg.severity
+	if w.cfg.detectSeverity {
+		if sn, text, ok := detectSeverity(msg); ok {
+			severity = sn
+			lrc.SeverityText = &text
+		}
+	}
+	lrc.SeverityNumber = &severity
+	lrc.BodyValue = logs.StringValue(msg)
+	if len(attrs) > 0 {
+		lrc.Attributes = &attrs
+	}
+
+	w.logger.EmitContext(context.Background(), logs.NewLogRecord(lrc))
+}
+
+// parseHeader removes the header a log.Logger configured with the prefix and
+// flags of the Writer adds to msg and records what it holds.
+func (w *Writer) parseHeader(msg string, attrs []attribute.KeyValue, ts *time.Time) (string, []attribute.KeyValue, *time.Time) {
+	prefix, flag := w.cfg.prefix, w.cfg.flag
+	if prefix == "" && flag == 0 {
+		return msg, attrs, ts
+	}
+
+	if prefix != "" && flag&log.Lmsgprefix == 0 && strings.HasPrefix(msg, prefix) {
+		msg = msg[len(prefix):]
+		attrs = append(attrs, PrefixKey.String(prefix))
+	}
+
+	if flag&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0 {
+		var layout string
+		if flag&log.Ldate != 0 {
+			layout = "2006/01/02 "
+		}
+		if flag&(log.Ltime|log.Lmicroseconds) != 0 {
+			layout += "15:04:05"
+			if flag&log.Lmicroseconds != 0 {
+				layout += ".000000"
+			}
+			layout += " "
+		}
+		if len(msg) >= len(layout) {
+			// Without a date, the time alone does not make a timestamp.
+			if flag&log.Ldate != 0 {
+				loc := time.Local
+				if flag&log.LUTC != 0 {
+					loc = time.UTC
+				}
+				if t, err := time.ParseInLocation(layout, msg[:len(layout)], loc); err == nil {
+					ts = &t
+				}
+			}
+			msg = msg[len(layout):]
+		}
+	}
+
+	if flag&(log.Lshortfile|log.Llongfile) != 0 {
+		if end := strings.Index(msg, ": "); end > 0 {
+			location := msg[:end]
+			if colon := strings.LastIndexByte(location, ':'); colon > 0 {
+				if line, err := strconv.Atoi(location[colon+1:]); err == nil {
+					attrs = append(attrs,
+						semconv.CodeFilepath(location[:colon]),
+						semconv.CodeLineNumber(line),
+					)
+					msg = msg[end+2:]
+				}
+			}
+		}
+	}
+
+	if prefix != "" && flag&log.Lmsgprefix != 0 && strings.HasPrefix(msg, prefix) {
+		msg = msg[len(prefix):]
+		attrs = append(attrs, PrefixKey.String(prefix))
+	}
+	return msg, attrs, ts
+}
+
+// detectSeverity returns the severity named by the first word of msg.
+func detectSeverity(msg string) (logs.SeverityNumber, string, bool) {
+	word := msg
+	if i := strings.IndexAny(msg, " \t"); i >= 0 {
+		word = msg[:i]
+	}
+	word = strings.Trim(word, "[]():")
+	if sn, err := logs.ParseSeverity(word); err == nil {
+		return sn, word, true
+	}
+	return logs.UNSPECIFIED, "", false
+}
@@ -0,0 +1,128 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelstdlog
+
+import (
+	"fmt"
+	"github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridgetest"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestWriterPartialWrites(t *testing.T) {
+	provider, exporter := bridgetest.NewLoggerProvider()
+	w := NewWriter("test", WithLoggerProvider(provider))
+
+	fmt.Fprint(w, "hello ")
+	assert.Empty(t, exporter.GetLogRecords())
+	fmt.Fprint(w, "world\n")
+	fmt.Fprint(w, "first line\nsecond line\n")
+	fmt.Fprint(w, "unterminated")
+	w.Flush()
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 3)
+	assert.Equal(t, "hello world", records[0].Body)
+	assert.Equal(t, logs.INFO, *records[0].SeverityNumber)
+	assert.Equal(t, "test", records[0].InstrumentationScope.Name)
+	assert.Equal(t, "first line\nsecond line", records[1].Body)
+	assert.Equal(t, "unterminated", records[2].Body)
+}
+
+func TestWriterChunkedLines(t *testing.T) {
+	provider, exporter := bridgetest.NewLoggerProvider()
+	w := NewWriter("test", WithLoggerProvider(provider))
+
+	fmt.Fprint(w, "a\nb")
+	fmt.Fprint(w, "c\n")
+	fmt.Fprint(w, "d\ne\nf")
+	fmt.Fprint(w, "g\n")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 5)
+	assert.Equal(t, "a", records[0].Body)
+	assert.Equal(t, "bc", records[1].Body)
+	assert.Equal(t, "d", records[2].Body)
+	assert.Equal(t, "e", records[3].Body)
+	assert.Equal(t, "fg", records[4].Body)
+}
+
+func TestWriterSplitLines(t *testing.T) {
+	provider, exporter := bridgetest.NewLoggerProvider()
+	w := NewWriter("test", WithLoggerProvider(provider), WithSplitLines(true), WithSeverity(logs.WARN))
+
+	fmt.Fprint(w, "first\nsec")
+	fmt.Fprint(w, "ond\n\nthird")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 2)
+	assert.Equal(t, "first", records[0].Body)
+	assert.Equal(t, "second", records[1].Body)
+	assert.Equal(t, logs.WARN, *records[1].SeverityNumber)
+}
+
+func TestWriterSeverityDetection(t *testing.T) {
+	provider, exporter := bridgetest.NewLoggerProvider()
+	w := NewWriter("test", WithLoggerProvider(provider), WithSeverityDetection(true))
+
+	fmt.Fprintln(w, "[ERROR] disk full")
+	fmt.Fprintln(w, "warning: low memory")
+	fmt.Fprintln(w, "just a message")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 3)
+	assert.Equal(t, logs.ERROR, *records[0].SeverityNumber)
+	assert.Equal(t, "ERROR", *records[0].SeverityText)
+	assert.Equal(t, "[ERROR] disk full", records[0].Body)
+	assert.Equal(t, logs.WARN, *records[1].SeverityNumber)
+	assert.Equal(t, logs.INFO, *records[2].SeverityNumber)
+	assert.Nil(t, records[2].SeverityText)
+}
+
+func TestNewLogger(t *testing.T) {
+	provider, exporter := bridgetest.NewLoggerProvider()
+	logger := NewLogger("test", "app: ", log.Ldate|log.Lmicroseconds|log.LUTC|log.Lshortfile, WithLoggerProvider(provider))
+
+	before := time.Now().Truncate(time.Microsecond)
+	logger.Printf("hello\nworld")
+
+	msgPrefix := NewLogger("test", "app: ", log.Lmsgprefix|log.Lshortfile, WithLoggerProvider(provider))
+	msgPrefix.Print("prefixed")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "hello\nworld", records[0].Body)
+	assert.False(t, records[0].Timestamp.Before(before))
+	attrs := attribute.NewSet(*records[0].Attributes...)
+	prefix, _ := attrs.Value(PrefixKey)
+	assert.Equal(t, "app: ", prefix.AsString())
+	file, _ := attrs.Value("code.filepath")
+	assert.Equal(t, "writer_test.go", file.AsString())
+	_, ok := attrs.Value("code.lineno")
+	assert.True(t, ok)
+
+	assert.Equal(t, "prefixed", records[1].Body)
+	attrs = attribute.NewSet(*records[1].Attributes...)
+	prefix, _ = attrs.Value(PrefixKey)
+	assert.Equal(t, "app: ", prefix.AsString())
+}
@@ -0,0 +1,72 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelzap
+
+import (
+	"github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridge"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+)
+
+// config contains options for the zap Core.
+type config struct {
+	bridge.Config
+}
+
+// newConfig creates a config configured with options.
+func newConfig(options ...Option) config {
+	var cfg config
+	for _, opt := range options {
+		cfg = opt.apply(cfg)
+	}
+	return cfg
+}
+
+// Option configures a Core.
+type Option interface {
+	apply(config) config
+}
+
+type optionFunc func(config) config
+
+func (fn optionFunc) apply(cfg config) config {
+	return fn(cfg)
+}
+
+// WithLoggerProvider sets the LoggerProvider used to create the Logger of the
+// Core. By default, the global LoggerProvider is used.
+func WithLoggerProvider(provider logs.LoggerProvider) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.Provider = provider
+		return cfg
+	})
+}
+
+// WithVersion sets the instrumentation version of the Logger.
+func WithVersion(version string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.Version = version
+		return cfg
+	})
+}
+
+// WithSchemaURL sets the schema URL of the Logger.
+func WithSchemaURL(schemaURL string) Option {
+	return optionFunc(func(cfg config) config {
+		cfg.SchemaURL = schemaURL
+		return cfg
+	})
+}
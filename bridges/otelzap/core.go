@@ -0,0 +1,143 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelzap
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.uber.org/zap/zapcore"
+	"time"
+)
+
+// Core is a zapcore.Core that emits zap entries as OpenTelemetry log records
+// through a logs.Logger.
+type Core struct {
+	logger logs.Logger
+	// fields are the fields added with With.
+	fields []zapcore.Field
+	// ctx is the context passed as a field to With, if any.
+	ctx context.Context
+}
+
+var _ zapcore.Core = (*Core)(nil)
+
+// NewCore returns a new Core that emits log records through a Logger for the
+// instrumentation scope name.
+func NewCore(name string, options ...Option) *Core {
+	cfg := newConfig(options...)
+	return &Core{
+		logger: cfg.Logger(name),
+		ctx:    context.Background(),
+	}
+}
+
+// Enabled reports whether the Logger of the Core processes entries at level.
+func (c *Core) Enabled(level zapcore.Level) bool {
+	return c.logger.Enabled(c.ctx, logs.EnabledParameters{Severity: logs.SeverityFromZap(int8(level))})
+}
+
+// With returns a Core that adds fields to every entry it writes.
+//
+// A field holding a context.Context, e.g. zap.Any("context", ctx), is not
+// recorded as an attribute, its trace context is used for the log records
+// instead.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	c2 := *c
+	c2.fields = make([]zapcore.Field, len(c.fields), len(c.fields)+len(fields))
+	copy(c2.fields, c.fields)
+	for _, f := range fields {
+		if ctx, ok := f.Interface.(context.Context); ok {
+			c2.ctx = ctx
+			continue
+		}
+		c2.fields = append(c2.fields, f)
+	}
+	return &c2
+}
+
+// Check adds the Core to ce if the entry level is enabled.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write emits ent with fields as a log record.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ctx := c.ctx
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		if fctx, ok := f.Interface.(context.Context); ok {
+			ctx = fctx
+			continue
+		}
+		f.AddTo(enc)
+	}
+
+	attrs, structured := attributes(enc.Fields)
+	if ent.Caller.Defined {
+		attrs = append(attrs,
+			semconv.CodeFilepath(ent.Caller.File),
+			semconv.CodeLineNumber(ent.Caller.Line),
+		)
+		if ent.Caller.Function != "" {
+			attrs = append(attrs, semconv.CodeFunction(ent.Caller.Function))
+		}
+	}
+	if ent.Stack != "" {
+		attrs = append(attrs, semconv.CodeStacktrace(ent.Stack))
+	}
+
+	severity := logs.SeverityFromZap(int8(ent.Level))
+	severityText := ent.Level.CapitalString()
+	lrc := logs.LogRecordConfig{
+		ObservedTimestamp:    time.Now(),
+		SeverityText:         &severityText,
+		SeverityNumber:       &severity,
+		BodyValue:            logs.StringValue(ent.Message),
+		Attributes:           &attrs,
+		StructuredAttributes: structured,
+	}
+	if !ent.Time.IsZero() {
+		lrc.Timestamp = &ent.Time
+	}
+
+	c.logger.EmitContext(ctx, logs.NewLogRecord(lrc))
+	return nil
+}
+
+// Sync does nothing, log records are flushed by the LoggerProvider.
+func (c *Core) Sync() error {
+	return nil
+}
+
+// attributes converts the fields encoded by a zapcore.MapObjectEncoder into
+// attributes and structured attributes sorted by key.
+func attributes(fields map[string]any) ([]attribute.KeyValue, []logs.KeyValue) {
+	attrs := make([]attribute.KeyValue, 0, len(fields))
+	var structured []logs.KeyValue
+	for _, key := range sortedKeys(fields) {
+		attrs, structured = appendAttributes(attrs, structured, key, fields[key])
+	}
+	return attrs, structured
+}
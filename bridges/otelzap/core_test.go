@@ -0,0 +1,144 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelzap
+
+import (
+	"context"
+	"errors"
+	"github.com/agoda-com/opentelemetry-logs-go/bridges/internal/bridgetest"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	sdk "github.com/agoda-com/opentelemetry-logs-go/sdk/logs"
+	"github.com/agoda-com/opentelemetry-logs-go/sdk/logs/logstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"testing"
+)
+
+func newTestLogger(options ...zap.Option) (*zap.Logger, *logstest.InMemoryExporter) {
+	provider, exporter := bridgetest.NewLoggerProvider()
+	core := NewCore("test", WithLoggerProvider(provider), WithVersion("v0.1.0"))
+	return zap.New(core, options...), exporter
+}
+
+type user struct {
+	name string
+	age  int
+}
+
+func (u user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.name)
+	enc.AddInt("age", u.age)
+	return nil
+}
+
+func TestCore(t *testing.T) {
+	logger, exporter := newTestLogger()
+
+	logger.Warn("hello",
+		zap.String("string", "value"),
+		zap.Int("int", 3),
+		zap.Bool("bool", true),
+		zap.Error(errors.New("boom")),
+		zap.Object("user", user{name: "alice", age: 30}),
+		zap.Strings("tags", []string{"a", "b"}),
+		zap.Array("users", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+			return enc.AppendObject(user{name: "bob", age: 40})
+		})),
+	)
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	record := records[0]
+	assert.Equal(t, "hello", record.Body)
+	assert.Equal(t, logs.WARN, *record.SeverityNumber)
+	assert.Equal(t, "WARN", *record.SeverityText)
+	assert.NotNil(t, record.Timestamp)
+	assert.Equal(t, "test", record.InstrumentationScope.Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Bool("bool", true),
+		attribute.String("error", "boom"),
+		attribute.Int("int", 3),
+		attribute.String("string", "value"),
+		attribute.StringSlice("tags", []string{"a", "b"}),
+	}, *record.Attributes)
+	assert.Equal(t, []logs.KeyValue{
+		logs.Map("user", logs.Int64("age", 30), logs.String("name", "alice")),
+		logs.Slice("users", logs.MapValue(logs.Int64("age", 40), logs.String("name", "bob"))),
+	}, record.StructuredAttributes)
+}
+
+func TestCoreWith(t *testing.T) {
+	logger, exporter := newTestLogger(zap.AddCaller())
+
+	traceID, _ := trace.TraceIDFromHex("80f198ee56343ba864fe8b2a57d3eff7")
+	spanID, _ := trace.SpanIDFromHex("2a00000000000000")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	logger.With(zap.String("service", "api"), zap.Any("context", ctx)).Info("with context")
+	logger.Info("field context", zap.Any("context", ctx))
+	logger.Info("no context")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 3)
+
+	assert.Equal(t, traceID, *records[0].TraceId)
+	assert.Equal(t, spanID, *records[0].SpanId)
+	attrs := attribute.NewSet(*records[0].Attributes...)
+	service, _ := attrs.Value("service")
+	assert.Equal(t, "api", service.AsString())
+	_, ok := attrs.Value("context")
+	assert.False(t, ok)
+	file, ok := attrs.Value("code.filepath")
+	assert.True(t, ok)
+	assert.Contains(t, file.AsString(), "core_test.go")
+
+	assert.Equal(t, traceID, *records[1].TraceId)
+	assert.Nil(t, records[2].TraceId)
+}
+
+func TestCoreEnabled(t *testing.T) {
+	core := NewCore("test", WithLoggerProvider(sdk.NewLoggerProvider()))
+	assert.False(t, core.Enabled(zapcore.ErrorLevel))
+	assert.Nil(t, core.Check(zapcore.Entry{Level: zapcore.ErrorLevel}, nil))
+
+	logger, exporter := newTestLogger()
+	logger.DPanic("dpanic")
+
+	records := exporter.GetLogRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, logs.FATAL, *records[0].SeverityNumber)
+	assert.Equal(t, "DPANIC", *records[0].SeverityText)
+}
+
+func TestAppendAttributes(t *testing.T) {
+	attrs, structured := appendAttributes(nil, nil, "mixed", []any{"a", 1, map[string]any{"k": "v"}})
+	assert.Empty(t, attrs)
+	assert.Equal(t, []logs.KeyValue{
+		logs.Slice("mixed", logs.StringValue("a"), logs.Int64Value(1), logs.MapValue(logs.String("k", "v"))),
+	}, structured)
+
+	attrs, structured = appendAttributes(nil, nil, "ints", []any{int8(1), uint16(2), int64(3)})
+	assert.Equal(t, []attribute.KeyValue{attribute.Int64Slice("ints", []int64{1, 2, 3})}, attrs)
+	assert.Empty(t, structured)
+}
@@ -0,0 +1,33 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package otelzap provides a zapcore.Core that bridges zap entries into
+OpenTelemetry log records.
+
+	logger := zap.New(otelzap.NewCore("my/package"), zap.AddCaller())
+	logger.Info("hello", zap.String("user", "alice"), zap.Any("context", ctx))
+
+Zap levels are mapped onto severity numbers: debug becomes DEBUG, info INFO,
+warn WARN, error ERROR, dpanic FATAL, panic FATAL2 and fatal FATAL3. The
+trace context is taken from a field holding a context.Context, such as
+zap.Any("context", ctx), passed to the log call or to With.
+
+Fields are recorded as attributes. Objects and arrays that are not made of
+scalars of one type, such as zap.Object and zap.Array fields, are recorded
+as map and slice structured attributes.
+*/
+package otelzap // import "github.com/agoda-com/opentelemetry-logs-go/bridges/otelzap"
@@ -0,0 +1,192 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelzap
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	"math"
+	"sort"
+	"time"
+)
+
+// appendAttributes appends the attribute for the value v encoded by a
+// zapcore.MapObjectEncoder to attrs, or to structured if it has no attribute
+// representation.
+//
+// Objects become map structured attributes. Arrays of scalars of the same
+// type become slice attributes, other arrays become slice structured
+// attributes.
+func appendAttributes(attrs []attribute.KeyValue, structured []logs.KeyValue, key string, v any) ([]attribute.KeyValue, []logs.KeyValue) {
+	switch val := v.(type) {
+	case nil:
+		return attrs, structured
+	case map[string]any, []any:
+		if sv, ok := sliceValue(val); ok {
+			return append(attrs, attribute.KeyValue{Key: attribute.Key(key), Value: sv}), structured
+		}
+		return attrs, append(structured, logs.KeyValue{Key: key, Value: logsValue(val)})
+	}
+	return append(attrs, attribute.KeyValue{Key: attribute.Key(key), Value: scalarValue(v)}), structured
+}
+
+// logsValue converts a value encoded by a zapcore.MapObjectEncoder, keeping
+// the structure of objects and arrays.
+func logsValue(v any) logs.Value {
+	switch val := v.(type) {
+	case nil:
+		return logs.Value{}
+	case map[string]any:
+		kvs := make([]logs.KeyValue, 0, len(val))
+		for _, k := range sortedKeys(val) {
+			kvs = append(kvs, logs.KeyValue{Key: k, Value: logsValue(val[k])})
+		}
+		return logs.MapValue(kvs...)
+	case []any:
+		values := make([]logs.Value, len(val))
+		for i, elem := range val {
+			values[i] = logsValue(elem)
+		}
+		return logs.SliceValue(values...)
+	}
+
+	sv := scalarValue(v)
+	switch sv.Type() {
+	case attribute.BOOL:
+		return logs.BoolValue(sv.AsBool())
+	case attribute.INT64:
+		return logs.Int64Value(sv.AsInt64())
+	case attribute.FLOAT64:
+		return logs.Float64Value(sv.AsFloat64())
+	default:
+		return logs.StringValue(sv.AsString())
+	}
+}
+
+// sliceValue returns the slice attribute value for v if it is an array of
+// scalars of the same type.
+func sliceValue(v any) (attribute.Value, bool) {
+	elems, ok := v.([]any)
+	if !ok || len(elems) == 0 {
+		return attribute.Value{}, false
+	}
+	kind := scalarValue(elems[0]).Type()
+	values := make([]attribute.Value, len(elems))
+	for i, elem := range elems {
+		switch elem.(type) {
+		case map[string]any, []any, nil:
+			return attribute.Value{}, false
+		}
+		values[i] = scalarValue(elem)
+		if values[i].Type() != kind {
+			return attribute.Value{}, false
+		}
+	}
+
+	switch kind {
+	case attribute.BOOL:
+		s := make([]bool, len(values))
+		for i, v := range values {
+			s[i] = v.AsBool()
+		}
+		return attribute.BoolSliceValue(s), true
+	case attribute.INT64:
+		s := make([]int64, len(values))
+		for i, v := range values {
+			s[i] = v.AsInt64()
+		}
+		return attribute.Int64SliceValue(s), true
+	case attribute.FLOAT64:
+		s := make([]float64, len(values))
+		for i, v := range values {
+			s[i] = v.AsFloat64()
+		}
+		return attribute.Float64SliceValue(s), true
+	default:
+		s := make([]string, len(values))
+		for i, v := range values {
+			s[i] = v.AsString()
+		}
+		return attribute.StringSliceValue(s), true
+	}
+}
+
+// scalarValue converts a scalar value encoded by a zapcore.MapObjectEncoder.
+func scalarValue(v any) attribute.Value {
+	switch val := v.(type) {
+	case string:
+		return attribute.StringValue(val)
+	case bool:
+		return attribute.BoolValue(val)
+	case int:
+		return attribute.IntValue(val)
+	case int64:
+		return attribute.Int64Value(val)
+	case int32:
+		return attribute.Int64Value(int64(val))
+	case int16:
+		return attribute.Int64Value(int64(val))
+	case int8:
+		return attribute.Int64Value(int64(val))
+	case uint:
+		return uintValue(uint64(val))
+	case uint64:
+		return uintValue(val)
+	case uint32:
+		return attribute.Int64Value(int64(val))
+	case uint16:
+		return attribute.Int64Value(int64(val))
+	case uint8:
+		return attribute.Int64Value(int64(val))
+	case uintptr:
+		return uintValue(uint64(val))
+	case float64:
+		return attribute.Float64Value(val)
+	case float32:
+		return attribute.Float64Value(float64(val))
+	case time.Duration:
+		return attribute.Int64Value(val.Nanoseconds())
+	case time.Time:
+		return attribute.StringValue(val.Format(time.RFC3339Nano))
+	case []byte:
+		return attribute.StringValue(base64.StdEncoding.EncodeToString(val))
+	case error:
+		return attribute.StringValue(val.Error())
+	case fmt.Stringer:
+		return attribute.StringValue(val.String())
+	default:
+		return attribute.StringValue(fmt.Sprintf("%+v", val))
+	}
+}
+
+func uintValue(v uint64) attribute.Value {
+	if v > math.MaxInt64 {
+		v = math.MaxInt64
+	}
+	return attribute.Int64Value(int64(v))
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -17,57 +17,84 @@ limitations under the License.
 package logstransform
 
 import (
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	sdk "github.com/agoda-com/opentelemetry-logs-go/sdk/logs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
 	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
-	"math"
-	"reflect"
-	"sort"
-	"strings"
 	"time"
 )
 
-// Logs transforms OpenTelemetry LogRecord's into a OTLP ResourceLogs
+// Logs transforms OpenTelemetry LogRecord's into a OTLP ResourceLogs.
+//
+// Log records are grouped by resource and then by instrumentation scope, so
+// every resource and scope is sent only once per batch.
 func Logs(sdl []sdk.ReadableLogRecord) []*logspb.ResourceLogs {
+	if len(sdl) == 0 {
+		return nil
+	}
 
-	var resourceLogs []*logspb.ResourceLogs
+	type scopeKey struct {
+		r  attribute.Distinct
+		is instrumentation.Scope
+	}
 
-	for _, sd := range sdl {
+	rlm := make(map[attribute.Distinct]*logspb.ResourceLogs)
+	slm := make(map[scopeKey]*logspb.ScopeLogs)
 
-		lr := logRecord(sd)
+	var resourceLogs []*logspb.ResourceLogs
+	for _, sd := range sdl {
+		if sd == nil {
+			continue
+		}
 
-		var is *commonpb.InstrumentationScope
-		var schemaURL = ""
-		if sd.InstrumentationScope() != nil {
-			is = &commonpb.InstrumentationScope{
-				Name:    sd.InstrumentationScope().Name,
-				Version: sd.InstrumentationScope().Version,
+		rKey := sd.Resource().Equivalent()
+		rl, ok := rlm[rKey]
+		if !ok {
+			rl = &logspb.ResourceLogs{
+				Resource: &resourcepb.Resource{
+					Attributes: KeyValues(sd.Resource().Attributes()),
+				},
+				SchemaUrl: sd.Resource().SchemaURL(),
 			}
-			schemaURL = sd.InstrumentationScope().SchemaURL
+			rlm[rKey] = rl
+			resourceLogs = append(resourceLogs, rl)
 		}
 
-		// Create a log resource
-		resourceLog := &logspb.ResourceLogs{
-			Resource: &resourcepb.Resource{
-				Attributes: KeyValues(sd.Resource().Attributes()),
-			},
-			// provide a resource description if available
-			ScopeLogs: []*logspb.ScopeLogs{
-				{
-					Scope:      is,
-					SchemaUrl:  schemaURL,
-					LogRecords: []*logspb.LogRecord{lr},
-				},
-			},
+		var sKey = scopeKey{r: rKey}
+		if sd.InstrumentationScope() != nil {
+			sKey.is = *sd.InstrumentationScope()
+		}
+		sl, ok := slm[sKey]
+		if !ok {
+			sl = scopeLogs(sd.InstrumentationScope())
+			slm[sKey] = sl
+			rl.ScopeLogs = append(rl.ScopeLogs, sl)
 		}
 
-		resourceLogs = append(resourceLogs, resourceLog)
+		sl.LogRecords = append(sl.LogRecords, logRecord(sd))
 	}
 
 	return resourceLogs
 }
 
+// scopeLogs creates an empty ScopeLogs for the instrumentation scope is.
+func scopeLogs(is *instrumentation.Scope) *logspb.ScopeLogs {
+	if is == nil {
+		return &logspb.ScopeLogs{}
+	}
+	return &logspb.ScopeLogs{
+		Scope: &commonpb.InstrumentationScope{
+			Name:       is.Name,
+			Version:    is.Version,
+			Attributes: Iterator(is.Attributes.Iter()),
+		},
+		SchemaUrl: is.SchemaURL,
+	}
+}
+
 func logRecord(record sdk.ReadableLogRecord) *logspb.LogRecord {
 	var traceIDBytes []byte
 	if record.TraceId() != nil {
@@ -95,6 +122,9 @@ func logRecord(record sdk.ReadableLogRecord) *logspb.LogRecord {
 	if record.Attributes() != nil {
 		kv = KeyValues(*record.Attributes())
 	}
+	if sa := record.StructuredAttributes(); len(sa) > 0 {
+		kv = append(kv, LogKeyValues(sa)...)
+	}
 
 	var st = ""
 	if record.SeverityText() != nil {
@@ -107,205 +137,89 @@ func logRecord(record sdk.ReadableLogRecord) *logspb.LogRecord {
 	}
 
 	logRecord := &logspb.LogRecord{
-		TimeUnixNano:         uint64(ts.UnixNano()),
-		ObservedTimeUnixNano: uint64(record.ObservedTimestamp().UnixNano()),
-		TraceId:              traceIDBytes,                   // provide the associated trace ID if available
-		SpanId:               spanIDBytes,                    // provide the associated span ID if available
-		Flags:                uint32(traceFlags),             // provide the associated trace flags
-		Body:                 valueToAnyValue(record.Body()), // provide the associated log body if available
-		Attributes:           kv,                             // provide additional log attributes if available
-		SeverityText:         st,
-		SeverityNumber:       sn,
+		TimeUnixNano:           uint64(ts.UnixNano()),
+		ObservedTimeUnixNano:   uint64(record.ObservedTimestamp().UnixNano()),
+		TraceId:                traceIDBytes,                 // provide the associated trace ID if available
+		SpanId:                 spanIDBytes,                  // provide the associated span ID if available
+		Flags:                  uint32(traceFlags),           // provide the associated trace flags
+		Body:                   LogValue(record.BodyValue()), // provide the associated log body if available
+		Attributes:             kv,                           // provide additional log attributes if available
+		SeverityText:           st,
+		SeverityNumber:         sn,
+		EventName:              record.EventName(),
+		DroppedAttributesCount: uint32(record.DroppedAttributesCount()),
 	}
 	return logRecord
 }
 
-func valueToAnyValue(value any) *commonpb.AnyValue {
-	if value == nil {
-		return nil
-	}
-	typ := reflect.TypeOf(value)
-	val := reflect.ValueOf(value)
-	if valueIsNil(typ, val) {
-		return nil
-	}
-	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem()
-		val = val.Elem()
-	}
-	switch {
-	case val.CanFloat():
+// LogValue transforms a logs.Value into an OTLP AnyValue. An empty Value is
+// transformed into nil.
+func LogValue(v logs.Value) *commonpb.AnyValue {
+	switch v.Kind() {
+	case logs.KindBool:
 		return &commonpb.AnyValue{
-			Value: &commonpb.AnyValue_DoubleValue{
-				DoubleValue: val.Float(),
+			Value: &commonpb.AnyValue_BoolValue{
+				BoolValue: v.AsBool(),
 			},
 		}
-	case val.CanInt():
+	case logs.KindInt64:
 		return &commonpb.AnyValue{
 			Value: &commonpb.AnyValue_IntValue{
-				IntValue: val.Int(),
+				IntValue: v.AsInt64(),
 			},
 		}
-	case val.Kind() == reflect.Bool:
+	case logs.KindFloat64:
 		return &commonpb.AnyValue{
-			Value: &commonpb.AnyValue_BoolValue{
-				BoolValue: val.Bool(),
+			Value: &commonpb.AnyValue_DoubleValue{
+				DoubleValue: v.AsFloat64(),
 			},
 		}
-	case val.CanUint():
-		valUInt := val.Uint()
-		if valUInt > math.MaxInt64 {
-			valUInt = math.MaxInt64
-		}
+	case logs.KindString:
 		return &commonpb.AnyValue{
-			Value: &commonpb.AnyValue_IntValue{
-				IntValue: int64(valUInt),
+			Value: &commonpb.AnyValue_StringValue{
+				StringValue: v.AsString(),
 			},
 		}
-	case val.Kind() == reflect.String:
+	case logs.KindBytes:
 		return &commonpb.AnyValue{
-			Value: &commonpb.AnyValue_StringValue{
-				StringValue: val.String(),
+			Value: &commonpb.AnyValue_BytesValue{
+				BytesValue: v.AsBytes(),
 			},
 		}
-	case typ.ConvertibleTo(reflect.TypeOf(time.Time{})):
-		valTime := val.Convert(reflect.TypeOf(time.Time{})).Interface().(time.Time)
-		if valTime.IsZero() {
-			return nil
+	case logs.KindSlice:
+		elems := make([]*commonpb.AnyValue, 0, len(v.AsSlice()))
+		for _, elem := range v.AsSlice() {
+			elems = append(elems, LogValue(elem))
 		}
 		return &commonpb.AnyValue{
-			Value: &commonpb.AnyValue_StringValue{
-				StringValue: valTime.Format(time.RFC3339Nano),
+			Value: &commonpb.AnyValue_ArrayValue{
+				ArrayValue: &commonpb.ArrayValue{
+					Values: elems,
+				},
 			},
 		}
-	case (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) && typ.Elem() == reflect.TypeOf(byte(0)):
-		return byteSliceToAnyValue(val)
-	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
-		return sliceToAnyValue(val)
-	case typ.Kind() == reflect.Map:
-		return mapToAnyValue(val)
-	case typ.Kind() == reflect.Struct:
-		return structToAnyValue(typ, val)
-	default:
+	case logs.KindMap:
 		return &commonpb.AnyValue{
-			Value: &commonpb.AnyValue_StringValue{
-				StringValue: val.String(),
+			Value: &commonpb.AnyValue_KvlistValue{
+				KvlistValue: &commonpb.KeyValueList{
+					Values: LogKeyValues(v.AsMap()),
+				},
 			},
 		}
-	}
-}
-
-func byteSliceToAnyValue(val reflect.Value) *commonpb.AnyValue {
-	sliceLen := val.Len()
-	if sliceLen == 0 {
-		return nil
-	}
-	out := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(byte(1))), sliceLen, sliceLen)
-	reflect.Copy(out, val)
-	return &commonpb.AnyValue{
-		Value: &commonpb.AnyValue_BytesValue{
-			BytesValue: out.Interface().([]byte),
-		},
-	}
-}
-
-func sliceToAnyValue(val reflect.Value) *commonpb.AnyValue {
-	sliceLen := val.Len()
-	if sliceLen == 0 {
+	default:
 		return nil
 	}
-	elems := make([]*commonpb.AnyValue, 0, sliceLen)
-	for i := 0; i < val.Len(); i++ {
-		elem := val.Index(i)
-		if !elem.CanInterface() {
-			continue
-		}
-		elems = append(elems, valueToAnyValue(elem.Interface()))
-	}
-	return &commonpb.AnyValue{
-		Value: &commonpb.AnyValue_ArrayValue{
-			ArrayValue: &commonpb.ArrayValue{
-				Values: elems,
-			},
-		},
-	}
-}
-
-func structToAnyValue(typ reflect.Type, val reflect.Value) *commonpb.AnyValue {
-	nFields := typ.NumField()
-	attrs := make([]*commonpb.KeyValue, 0, nFields)
-	for i := 0; i < nFields; i++ {
-		fieldType := typ.Field(i)
-		if !fieldType.IsExported() {
-			continue
-		}
-		fieldValue := val.Field(i)
-		if !fieldValue.CanInterface() {
-			continue
-		}
-		fieldName := fieldType.Name
-		if jsonTag, exists := fieldType.Tag.Lookup("json"); exists {
-			fieldName = strings.Split(jsonTag, ",")[0]
-		}
-		fieldAnyValue := valueToAnyValue(fieldValue.Interface())
-		if fieldAnyValue == nil {
-			continue
-		}
-		attr := &commonpb.KeyValue{
-			Key:   fieldName,
-			Value: fieldAnyValue,
-		}
-		attrs = append(attrs, attr)
-	}
-	return &commonpb.AnyValue{
-		Value: &commonpb.AnyValue_KvlistValue{
-			KvlistValue: &commonpb.KeyValueList{
-				Values: attrs,
-			},
-		},
-	}
 }
 
-func mapToAnyValue(val reflect.Value) *commonpb.AnyValue {
-	nFields := val.Len()
-	elems := make([]*commonpb.KeyValue, 0, nFields)
-	mapKeys := val.MapKeys()
-	sort.SliceStable(mapKeys, func(i, j int) bool {
-		return mapKeys[i].String() < mapKeys[j].String()
-	})
-	for _, mapKey := range mapKeys {
-		mapValue := val.MapIndex(mapKey)
-		if !mapValue.CanInterface() {
-			continue
-		}
-		mapValueAnyValue := valueToAnyValue(mapValue.Interface())
-		if mapValueAnyValue == nil {
-			continue
-		}
-		elem := &commonpb.KeyValue{
-			Key:   mapKey.String(),
-			Value: mapValueAnyValue,
-		}
-		elems = append(elems, elem)
-	}
-	return &commonpb.AnyValue{
-		Value: &commonpb.AnyValue_KvlistValue{
-			KvlistValue: &commonpb.KeyValueList{
-				Values: elems,
-			},
-		},
-	}
-}
-
-func valueIsNil(typ reflect.Type, val reflect.Value) bool {
-	if typ == nil {
-		return true
-	}
-	switch val.Kind() {
-	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.UnsafePointer,
-		reflect.Interface, reflect.Slice:
-		return val.IsNil()
-	default:
-		return false
-	}
+// LogKeyValues transforms a slice of logs.KeyValues, such as structured
+// attributes or the entries of a map logs.Value, into OTLP key-values.
+func LogKeyValues(kvs []logs.KeyValue) []*commonpb.KeyValue {
+	out := make([]*commonpb.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		out = append(out, &commonpb.KeyValue{
+			Key:   kv.Key,
+			Value: LogValue(kv.Value),
+		})
+	}
+	return out
 }
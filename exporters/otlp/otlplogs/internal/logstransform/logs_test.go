@@ -17,9 +17,13 @@ limitations under the License.
 package logstransform
 
 import (
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	logssdk "github.com/agoda-com/opentelemetry-logs-go/sdk/logs"
 	"github.com/agoda-com/opentelemetry-logs-go/sdk/logs/logstest"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
 	"math"
@@ -35,6 +39,74 @@ func TestEmptyLogRecord(t *testing.T) {
 	assert.Nil(t, Logs([]logssdk.ReadableLogRecord{}))
 }
 
+func TestLogsInstrumentationScope(t *testing.T) {
+	logTime := time.Unix(1589932800, 0000)
+	rl := Logs([]logssdk.ReadableLogRecord{logstest.LogRecordStub{
+		ObservedTimestamp: logTime,
+		Resource:          resource.Empty(),
+		InstrumentationScope: &instrumentation.Scope{
+			Name:       "test",
+			Version:    "v0.1.0",
+			SchemaURL:  "https://opentelemetry.io/schemas/1.27.0",
+			Attributes: attribute.NewSet(attribute.String("key", "value")),
+		},
+	}.Snapshot()})
+
+	assert.Len(t, rl, 1)
+	assert.Len(t, rl[0].ScopeLogs, 1)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.27.0", rl[0].ScopeLogs[0].SchemaUrl)
+	assert.Equal(t, &commonpb.InstrumentationScope{
+		Name:    "test",
+		Version: "v0.1.0",
+		Attributes: []*commonpb.KeyValue{
+			{
+				Key: "key",
+				Value: &commonpb.AnyValue{
+					Value: &commonpb.AnyValue_StringValue{StringValue: "value"},
+				},
+			},
+		},
+	}, rl[0].ScopeLogs[0].Scope)
+}
+
+func TestLogsGrouping(t *testing.T) {
+	logTime := time.Unix(1589932800, 0000)
+	r1 := resource.NewSchemaless(attribute.String("service.name", "one"))
+	r2 := resource.NewSchemaless(attribute.String("service.name", "two"))
+	scopeA := &instrumentation.Scope{Name: "a"}
+	scopeB := &instrumentation.Scope{Name: "b"}
+
+	record := func(r *resource.Resource, is *instrumentation.Scope) logssdk.ReadableLogRecord {
+		return logstest.LogRecordStub{
+			ObservedTimestamp:    logTime,
+			Resource:             r,
+			InstrumentationScope: is,
+		}.Snapshot()
+	}
+
+	rl := Logs([]logssdk.ReadableLogRecord{
+		record(r1, scopeA),
+		record(r2, scopeA),
+		record(r1, scopeB),
+		record(r1, scopeA),
+		// Equal resources built separately share the same ResourceLogs.
+		record(resource.NewSchemaless(attribute.String("service.name", "one")), &instrumentation.Scope{Name: "b"}),
+	})
+
+	assert.Len(t, rl, 2)
+	assert.Equal(t, KeyValues(r1.Attributes()), rl[0].Resource.Attributes)
+	assert.Len(t, rl[0].ScopeLogs, 2)
+	assert.Equal(t, "a", rl[0].ScopeLogs[0].Scope.Name)
+	assert.Len(t, rl[0].ScopeLogs[0].LogRecords, 2)
+	assert.Equal(t, "b", rl[0].ScopeLogs[1].Scope.Name)
+	assert.Len(t, rl[0].ScopeLogs[1].LogRecords, 2)
+
+	assert.Equal(t, KeyValues(r2.Attributes()), rl[1].Resource.Attributes)
+	assert.Len(t, rl[1].ScopeLogs, 1)
+	assert.Equal(t, "a", rl[1].ScopeLogs[0].Scope.Name)
+	assert.Len(t, rl[1].ScopeLogs[0].LogRecords, 1)
+}
+
 type logStruct struct {
 	StringField     string
 	IntField        int
@@ -51,6 +123,73 @@ type logStruct struct {
 	NilField        *logStruct
 }
 
+func TestLogValue(t *testing.T) {
+	assert.Nil(t, LogValue(logs.Value{}))
+
+	lr := logRecord(logstest.LogRecordStub{
+		BodyValue: logs.MapValue(
+			logs.String("name", "api"),
+			logs.Slice("codes", logs.IntValue(200), logs.BoolValue(true)),
+			logs.Bytes("raw", []byte{1}),
+			logs.Float64("ratio", 0.5),
+		),
+	}.Snapshot())
+
+	assert.Equal(t, &commonpb.AnyValue{
+		Value: &commonpb.AnyValue_KvlistValue{
+			KvlistValue: &commonpb.KeyValueList{
+				Values: []*commonpb.KeyValue{
+					{Key: "name", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "api"}}},
+					{Key: "codes", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{
+						Values: []*commonpb.AnyValue{
+							{Value: &commonpb.AnyValue_IntValue{IntValue: 200}},
+							{Value: &commonpb.AnyValue_BoolValue{BoolValue: true}},
+						},
+					}}}},
+					{Key: "raw", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: []byte{1}}}},
+					{Key: "ratio", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: 0.5}}},
+				},
+			},
+		},
+	}, lr.Body)
+}
+
+func TestLogRecordStructuredAttributes(t *testing.T) {
+	attrs := []attribute.KeyValue{attribute.Int("count", 1)}
+	lr := logRecord(logstest.LogRecordStub{
+		Attributes: &attrs,
+		StructuredAttributes: []logs.KeyValue{
+			logs.Map("user", logs.String("name", "ann")),
+			logs.Slice("mixed", logs.StringValue("x"), logs.IntValue(2)),
+		},
+	}.Snapshot())
+
+	assert.Equal(t, []*commonpb.KeyValue{
+		{Key: "count", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: 1}}},
+		{Key: "user", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{
+			Values: []*commonpb.KeyValue{
+				{Key: "name", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "ann"}}},
+			},
+		}}}},
+		{Key: "mixed", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{
+			Values: []*commonpb.AnyValue{
+				{Value: &commonpb.AnyValue_StringValue{StringValue: "x"}},
+				{Value: &commonpb.AnyValue_IntValue{IntValue: 2}},
+			},
+		}}}},
+	}, lr.Attributes)
+}
+
+func TestLogRecordDroppedAttributes(t *testing.T) {
+	lr := logRecord(logstest.LogRecordStub{DroppedAttributes: 3}.Snapshot())
+	assert.Equal(t, uint32(3), lr.DroppedAttributesCount)
+}
+
+func TestLogRecordEventName(t *testing.T) {
+	lr := logRecord(logstest.LogRecordStub{EventName: "session.start"}.Snapshot())
+	assert.Equal(t, "session.start", lr.EventName)
+}
+
 func TestLogRecord(t *testing.T) {
 	//attrs := []attribute.KeyValue{attribute.Int("one", 1), attribute.Int("two", 2)}
 	//eventTime := time.Date(2020, 5, 20, 0, 0, 0, 0, time.UTC)
@@ -73,6 +73,11 @@ func (e *Exporter) Export(ctx context.Context, logs []sdk.ReadableLogRecord) err
 		logMessageBuilder.WriteString(" ")
 		logMessageBuilder.WriteString(lr.getSeverityText())
 		logMessageBuilder.WriteString(" ")
+		if lr.EventName != "" {
+			logMessageBuilder.WriteString("[event: ")
+			logMessageBuilder.WriteString(lr.EventName)
+			logMessageBuilder.WriteString("] ")
+		}
 		if lr.Body != nil {
 			logMessageBuilder.WriteString(*lr.Body)
 			logMessageBuilder.WriteString(" ")
@@ -110,15 +115,23 @@ func (e *Exporter) Export(ctx context.Context, logs []sdk.ReadableLogRecord) err
 			attributes = append(attributes, *lr.Attributes...)
 		}
 
-		if len(attributes) > 0 {
+		if len(attributes)+len(lr.StructuredAttributes) > 0 {
 			logMessageBuilder.WriteString("{")
 			for i, a := range attributes {
+				if i > 0 {
+					logMessageBuilder.WriteString(", ")
+				}
 				logMessageBuilder.WriteString(string(a.Key))
 				logMessageBuilder.WriteString("=")
-				logMessageBuilder.WriteString(a.Value.AsString())
-				if i < len(attributes)-1 {
+				logMessageBuilder.WriteString(a.Value.Emit())
+			}
+			for i, kv := range lr.StructuredAttributes {
+				if i > 0 || len(attributes) > 0 {
 					logMessageBuilder.WriteString(", ")
 				}
+				logMessageBuilder.WriteString(kv.Key)
+				logMessageBuilder.WriteString("=")
+				logMessageBuilder.WriteString(kv.Value.String())
 			}
 			logMessageBuilder.WriteString("}")
 		}
@@ -81,6 +81,29 @@ func TestStdoutExporter(t *testing.T) {
 
 		actual := writer.String()
 
-		assert.Contains(t, actual, "INFO My message {service.name=otlplogs-example, service.version=0.0.1}")
+		assert.Contains(t, actual, "INFO My message [scopeInfo: github.com/instrumentron:v0.1.0] {service.name=otlplogs-example, service.version=0.0.1}")
 	}
 }
+
+func TestConvertBodyToString(t *testing.T) {
+	assert.Nil(t, convertBodyToString(logs.Value{}))
+	assert.Equal(t, "My message", *convertBodyToString(logs.StringValue("My message")))
+	assert.Equal(t, "{user:{id:7 name:ann}}", *convertBodyToString(logs.ValueOf(map[string]any{
+		"user": struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}{ID: 7, Name: "ann"},
+	})))
+}
+
+func TestStdoutExporterEvent(t *testing.T) {
+	var writer bytes.Buffer
+	exporter, err := NewExporter(WithWriter(&writer))
+	assert.NoError(t, err)
+	logger := sdk.NewLoggerProvider(sdk.WithSyncer(exporter)).Logger(instrumentationName)
+
+	logs.EmitEvent(context.Background(), logger, "checkout.completed", logs.Int("items", 3))
+
+	assert.Contains(t, writer.String(), "UNSPECIFIED [event: checkout.completed] [scopeInfo: github.com/instrumentron] {")
+	assert.Contains(t, writer.String(), "items=3}")
+}
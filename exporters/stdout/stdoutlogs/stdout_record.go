@@ -17,14 +17,12 @@ limitations under the License.
 package stdoutlogs
 
 import (
-	"fmt"
 	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	sdk "github.com/agoda-com/opentelemetry-logs-go/sdk/logs"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/trace"
-	"reflect"
 	"time"
 )
 
@@ -41,33 +39,15 @@ type stdOutLogRecord struct {
 	Resource             *resource.Resource
 	InstrumentationScope *instrumentation.Scope
 	Attributes           *[]attribute.KeyValue
+	StructuredAttributes []logs.KeyValue
+	EventName            string
 }
 
 func (lr stdOutLogRecord) getSeverityText() string {
 	if lr.SeverityNumber == nil {
-		return "UNSPECIFIED"
-	} else {
-		sn := int32(*lr.SeverityNumber)
-		if sn >= 1 && sn <= 4 {
-			return "TRACE"
-		}
-		if sn >= 5 && sn <= 8 {
-			return "DEBUG"
-		}
-		if sn >= 9 && sn <= 12 {
-			return "INFO"
-		}
-		if sn >= 13 && sn <= 16 {
-			return "WARN"
-		}
-		if sn >= 17 && sn <= 20 {
-			return "ERROR"
-		}
-		if sn >= 21 && sn <= 24 {
-			return "FATAL"
-		}
-		return "UNSPECIFIED"
+		return logs.UNSPECIFIED.Name()
 	}
+	return lr.SeverityNumber.Name()
 }
 
 func logRecordsFromReadableLogRecords(logRecords []sdk.ReadableLogRecord) []stdOutLogRecord {
@@ -83,51 +63,22 @@ func logRecordsFromReadableLogRecords(logRecords []sdk.ReadableLogRecord) []stdO
 			TraceFlags:           lr.TraceFlags(),
 			SeverityText:         lr.SeverityText(),
 			SeverityNumber:       lr.SeverityNumber(),
-			Body:                 convertBodyToString(lr.Body()),
+			Body:                 convertBodyToString(lr.BodyValue()),
 			Resource:             lr.Resource(),
 			InstrumentationScope: lr.InstrumentationScope(),
 			Attributes:           lr.Attributes(),
+			StructuredAttributes: lr.StructuredAttributes(),
+			EventName:            lr.EventName(),
 		}
 		result = append(result, logRecord)
 	}
 	return result
 }
 
-func convertBodyToString(body any) *string {
-	typ := reflect.TypeOf(body)
-	val := reflect.ValueOf(body)
-	if valueIsNil(typ, val) {
-		return nil
-	}
-	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem()
-		val = val.Elem()
-	}
-	if !val.CanInterface() {
+func convertBodyToString(body logs.Value) *string {
+	if body.Empty() {
 		return nil
 	}
-	var str string
-	switch {
-	case typ.ConvertibleTo(reflect.TypeOf(time.Time{})):
-		valTime := val.Convert(reflect.TypeOf(time.Time{})).Interface().(time.Time)
-		str = valTime.Format(time.RFC3339Nano)
-	case typ.Kind() == reflect.Struct:
-		str = fmt.Sprintf("%+v", val.Interface())
-	default:
-		str = fmt.Sprintf("%v", val.Interface())
-	}
+	str := body.String()
 	return &str
 }
-
-func valueIsNil(typ reflect.Type, val reflect.Value) bool {
-	if typ == nil {
-		return true
-	}
-	switch val.Kind() {
-	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.UnsafePointer,
-		reflect.Interface, reflect.Slice:
-		return val.IsNil()
-	default:
-		return false
-	}
-}
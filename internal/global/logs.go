@@ -17,6 +17,7 @@ limitations under the License.
 package global
 
 import (
+	"context"
 	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	"sync"
 	"sync/atomic"
@@ -117,6 +118,21 @@ func (t *logger) Emit(logRecord logs.LogRecord) {
 	}
 }
 
+func (t *logger) EmitContext(ctx context.Context, logRecord logs.LogRecord) {
+	delegate := t.delegate.Load()
+	if delegate != nil {
+		delegate.(logs.Logger).EmitContext(ctx, logRecord)
+	}
+}
+
+func (t *logger) Enabled(ctx context.Context, param logs.EnabledParameters) bool {
+	delegate := t.delegate.Load()
+	if delegate != nil {
+		return delegate.(logs.Logger).Enabled(ctx, param)
+	}
+	return false
+}
+
 // setDelegate configures t to delegate all Logger functionality to Loggers
 // created by provider.
 //
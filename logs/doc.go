@@ -33,9 +33,18 @@ operation being performed as part of a logging workflow. In its simplest form:
 
 		func operation(ctx context.Context) {
 	        logRecord := logger.NewLogRecord(..)
-	        logger.Emit(logRecord)
+	        logger.EmitContext(ctx, logRecord)
 		}
 
+The body of a LogRecord is a Value, built with constructors such as
+StringValue or MapValue. Go values passed as LogRecordConfig.BodyAny are
+converted with ValueOf instead.
+
+Events are log records with an event name. EmitEvent emits one with its
+attributes:
+
+	logs.EmitEvent(ctx, logger, "session.start", logs.String("user.id", id))
+
 A Logger is unique to the instrumentation and is used to create Logs.
 Instrumentation should be designed to accept a LoggerProvider from which it
 can create its own unique Logger. Alternatively, the registered global
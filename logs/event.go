@@ -0,0 +1,36 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"time"
+)
+
+// EmitEvent emits an event through logger within ctx. The event is a log
+// record with the event name name, the current time as its timestamp and
+// attrs as its structured attributes.
+// see https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-eventname
+func EmitEvent(ctx context.Context, logger Logger, name string, attrs ...KeyValue) {
+	now := time.Now()
+	logger.EmitContext(ctx, NewLogRecord(LogRecordConfig{
+		Timestamp:            &now,
+		ObservedTimestamp:    now,
+		StructuredAttributes: attrs,
+		EventName:            name,
+	}))
+}
@@ -0,0 +1,54 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type recordingLogger struct {
+	noopLogger
+	ctxs    []context.Context
+	records []LogRecord
+}
+
+func (l *recordingLogger) EmitContext(ctx context.Context, logRecord LogRecord) {
+	l.ctxs = append(l.ctxs, ctx)
+	l.records = append(l.records, logRecord)
+}
+
+type ctxKey struct{}
+
+func TestEmitEvent(t *testing.T) {
+	logger := &recordingLogger{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	EmitEvent(ctx, logger, "checkout.completed", String("cart.id", "c1"), Map("total", Int("cents", 1250)))
+
+	require.Len(t, logger.records, 1)
+	record := logger.records[0]
+	assert.Equal(t, ctx, logger.ctxs[0])
+	assert.Equal(t, "checkout.completed", record.EventName())
+	assert.Equal(t, []KeyValue{String("cart.id", "c1"), Map("total", Int("cents", 1250))}, record.StructuredAttributes())
+	require.NotNil(t, record.Timestamp())
+	assert.Equal(t, *record.Timestamp(), record.ObservedTimestamp())
+	assert.Nil(t, record.SeverityNumber())
+	assert.True(t, record.BodyValue().Empty())
+}
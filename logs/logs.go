@@ -17,6 +17,7 @@ limitations under the License.
 package logs
 
 import (
+	"context"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -34,19 +35,34 @@ type LogRecordConfig struct {
 	TraceFlags        *trace.TraceFlags
 	SeverityText      *string
 	SeverityNumber    *SeverityNumber
-	// Deprecated: use BodyAny instead.
-	Body                 *string
+	// Deprecated: use BodyValue instead.
+	Body *string
+	// Deprecated: use BodyValue instead. BodyAny is converted with ValueOf
+	// when BodyValue is empty.
 	BodyAny              any
+	BodyValue            Value
 	Resource             *resource.Resource
 	InstrumentationScope *instrumentation.Scope
 	Attributes           *[]attribute.KeyValue
+	// StructuredAttributes are attributes whose values can also be maps,
+	// slices of mixed kinds or bytes. They are exported along with
+	// Attributes.
+	StructuredAttributes []KeyValue
+	// EventName identifies the log record as an event of that name.
+	EventName string
 }
 
 // NewLogRecord constructs a LogRecord using values from the provided
 // LogRecordConfig.
 func NewLogRecord(config LogRecordConfig) LogRecord {
-	if config.BodyAny == nil && config.Body != nil {
-		config.BodyAny = *config.Body
+	body := config.BodyValue
+	var bodyAny any
+	if body.Empty() {
+		bodyAny = config.BodyAny
+		if bodyAny == nil && config.Body != nil {
+			bodyAny = *config.Body
+		}
+		body = ValueOf(bodyAny)
 	}
 	return LogRecord{
 		timestamp:            config.Timestamp,
@@ -56,10 +72,13 @@ func NewLogRecord(config LogRecordConfig) LogRecord {
 		traceFlags:           config.TraceFlags,
 		severityText:         config.SeverityText,
 		severityNumber:       config.SeverityNumber,
-		body:                 config.BodyAny,
+		body:                 body,
+		bodyAny:              bodyAny,
 		resource:             config.Resource,
 		instrumentationScope: config.InstrumentationScope,
 		attributes:           config.Attributes,
+		structuredAttributes: config.StructuredAttributes,
+		eventName:            config.EventName,
 	}
 }
 
@@ -74,10 +93,13 @@ type LogRecord struct {
 	traceFlags           *trace.TraceFlags
 	severityText         *string
 	severityNumber       *SeverityNumber
-	body                 any
+	body                 Value
+	bodyAny              any
 	resource             *resource.Resource
 	instrumentationScope *instrumentation.Scope
 	attributes           *[]attribute.KeyValue
+	structuredAttributes []KeyValue
+	eventName            string
 }
 
 func (l LogRecord) Timestamp() *time.Time                        { return l.timestamp }
@@ -87,12 +109,20 @@ func (l LogRecord) SpanId() *trace.SpanID                        { return l.span
 func (l LogRecord) TraceFlags() *trace.TraceFlags                { return l.traceFlags }
 func (l LogRecord) SeverityText() *string                        { return l.severityText }
 func (l LogRecord) SeverityNumber() *SeverityNumber              { return l.severityNumber }
-func (l LogRecord) Body() any                                    { return l.body }
+func (l LogRecord) BodyValue() Value                             { return l.body }
 func (l LogRecord) Resource() *resource.Resource                 { return l.resource }
 func (l LogRecord) InstrumentationScope() *instrumentation.Scope { return l.instrumentationScope }
 func (l LogRecord) Attributes() *[]attribute.KeyValue            { return l.attributes }
+func (l LogRecord) StructuredAttributes() []KeyValue             { return l.structuredAttributes }
+func (l LogRecord) EventName() string                            { return l.eventName }
 func (l LogRecord) private()                                     {}
 
+// Body returns the body as set with LogRecordConfig.Body, dereferenced, or
+// LogRecordConfig.BodyAny. It is nil if the body was set with BodyValue.
+//
+// Deprecated: use BodyValue instead.
+func (l LogRecord) Body() any { return l.bodyAny }
+
 // SeverityNumber Possible values for LogRecord.SeverityNumber.
 type SeverityNumber int32
 
@@ -125,10 +155,32 @@ const (
 	FATAL4      SeverityNumber = 24
 )
 
+// EnabledParameters describes a log record a caller is about to emit. It is
+// passed to Logger.Enabled.
+type EnabledParameters struct {
+	// Severity is the SeverityNumber of the log record.
+	Severity SeverityNumber
+	// EventName is the event name of the log record, if any.
+	EventName string
+}
+
 // Logger is the creator of Logs
 type Logger interface {
 	// Emit emits a log record
 	Emit(logRecord LogRecord)
+	// EmitContext emits a log record within the given context.
+	//
+	// TraceId, SpanId and TraceFlags that are not set on the log record are
+	// taken from the span context found in ctx, if any. The context is also
+	// passed on to the log record processors.
+	EmitContext(ctx context.Context, logRecord LogRecord)
+	// Enabled reports whether a log record with the given parameters would
+	// be processed if emitted within ctx.
+	//
+	// Bridges should call it before building a log record to skip the work
+	// for records that will be dropped anyway. A false result is only a hint,
+	// emitting the log record is still allowed.
+	Enabled(ctx context.Context, param EnabledParameters) bool
 }
 
 // LoggerProvider provides Loggers that are used by instrumentation code to
@@ -16,6 +16,8 @@ limitations under the License.
 
 package logs
 
+import "context"
+
 // NewNoopLoggerProvider returns an implementation of LoggerProvider that
 // performs no operations. The Logger created from the returned
 // LoggerProvider also perform no operations.
@@ -36,3 +38,7 @@ type noopLogger struct{}
 var _ Logger = noopLogger{}
 
 func (n noopLogger) Emit(logRecord LogRecord) {}
+
+func (n noopLogger) EmitContext(ctx context.Context, logRecord LogRecord) {}
+
+func (n noopLogger) Enabled(ctx context.Context, param EnabledParameters) bool { return false }
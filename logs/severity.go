@@ -0,0 +1,401 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// severityRanges are the names of the severity ranges of four severity
+// numbers each, starting with TRACE.
+var severityRanges = [...]string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// severityShortNames are the short names of the severity ranges.
+var severityShortNames = [...]string{"TRC", "DBG", "INF", "WRN", "ERR", "FTL"}
+
+// Valid reports whether s is one of the defined severity numbers other than
+// UNSPECIFIED.
+func (s SeverityNumber) Valid() bool {
+	return s >= TRACE && s <= FATAL4
+}
+
+// String returns the name of the severity number, such as "INFO" or "WARN3".
+func (s SeverityNumber) String() string {
+	if s == UNSPECIFIED {
+		return "UNSPECIFIED"
+	}
+	if !s.Valid() {
+		return "SeverityNumber(" + strconv.Itoa(int(s)) + ")"
+	}
+	name := severityRanges[(s-TRACE)/4]
+	if step := (s-TRACE)%4 + 1; step > 1 {
+		name += strconv.Itoa(int(step))
+	}
+	return name
+}
+
+// Name returns the name of the severity range s belongs to, such as "INFO"
+// for INFO to INFO4. It returns "UNSPECIFIED" for UNSPECIFIED and
+// undefined severity numbers.
+func (s SeverityNumber) Name() string {
+	if !s.Valid() {
+		return "UNSPECIFIED"
+	}
+	return severityRanges[(s-TRACE)/4]
+}
+
+// ShortName returns the three letter name of the severity range s belongs
+// to, such as "INF" for INFO to INFO4. It returns "UNS" for UNSPECIFIED and
+// undefined severity numbers.
+func (s SeverityNumber) ShortName() string {
+	if !s.Valid() {
+		return "UNS"
+	}
+	return severityShortNames[(s-TRACE)/4]
+}
+
+// IsTrace reports whether s is in the TRACE to TRACE4 range.
+func (s SeverityNumber) IsTrace() bool { return s >= TRACE && s <= TRACE4 }
+
+// IsDebug reports whether s is in the DEBUG to DEBUG4 range.
+func (s SeverityNumber) IsDebug() bool { return s >= DEBUG && s <= DEBUG4 }
+
+// IsInfo reports whether s is in the INFO to INFO4 range.
+func (s SeverityNumber) IsInfo() bool { return s >= INFO && s <= INFO4 }
+
+// IsWarn reports whether s is in the WARN to WARN4 range.
+func (s SeverityNumber) IsWarn() bool { return s >= WARN && s <= WARN4 }
+
+// IsError reports whether s is in the ERROR to ERROR4 range.
+func (s SeverityNumber) IsError() bool { return s >= ERROR && s <= ERROR4 }
+
+// IsFatal reports whether s is in the FATAL to FATAL4 range.
+func (s SeverityNumber) IsFatal() bool { return s >= FATAL && s <= FATAL4 }
+
+// severityAliases maps severity names used by logging libraries and syslog
+// onto severity numbers.
+var severityAliases = map[string]SeverityNumber{
+	"TRACE":         TRACE,
+	"TRC":           TRACE,
+	"DEBUG":         DEBUG,
+	"DBG":           DEBUG,
+	"INFO":          INFO,
+	"INF":           INFO,
+	"INFORMATION":   INFO,
+	"INFORMATIONAL": INFO,
+	"NOTICE":        INFO2,
+	"WARN":          WARN,
+	"WRN":           WARN,
+	"WARNING":       WARN,
+	"ERROR":         ERROR,
+	"ERR":           ERROR,
+	"CRIT":          ERROR2,
+	"CRITICAL":      ERROR2,
+	"ALERT":         ERROR3,
+	"DPANIC":        FATAL,
+	"EMERG":         FATAL,
+	"EMERGENCY":     FATAL,
+	"FATAL":         FATAL,
+	"FTL":           FATAL,
+	"PANIC":         FATAL2,
+}
+
+// ParseSeverity returns the severity number named by text. It accepts the
+// names returned by String and ShortName and the level names of syslog and
+// common logging libraries, such as "warning", "err", "crit" or "notice",
+// regardless of case.
+func ParseSeverity(text string) (SeverityNumber, error) {
+	name := strings.ToUpper(strings.TrimSpace(text))
+	if s, ok := severityAliases[name]; ok {
+		return s, nil
+	}
+	// Numbered severities such as "INFO2".
+	if n := len(name) - 1; n > 0 && name[n] >= '2' && name[n] <= '4' {
+		for i, r := range severityRanges {
+			if r == name[:n] {
+				return TRACE + SeverityNumber(i*4) + SeverityNumber(name[n]-'1'), nil
+			}
+		}
+	}
+	return UNSPECIFIED, fmt.Errorf("unknown severity %q", text)
+}
+
+// Syslog severity levels, see RFC 5424.
+const (
+	SyslogEmergency = 0
+	SyslogAlert     = 1
+	SyslogCritical  = 2
+	SyslogError     = 3
+	SyslogWarning   = 4
+	SyslogNotice    = 5
+	SyslogInfo      = 6
+	SyslogDebug     = 7
+)
+
+// syslogSeverities maps syslog severity levels onto severity numbers.
+// see https://opentelemetry.io/docs/specs/otel/logs/data-model-appendix/#appendix-b-severitynumber-example-mappings
+var syslogSeverities = [...]SeverityNumber{
+	SyslogEmergency: FATAL,
+	SyslogAlert:     ERROR3,
+	SyslogCritical:  ERROR2,
+	SyslogError:     ERROR,
+	SyslogWarning:   WARN,
+	SyslogNotice:    INFO2,
+	SyslogInfo:      INFO,
+	SyslogDebug:     DEBUG,
+}
+
+// SeverityFromSyslog returns the severity number of a syslog severity level.
+// Levels below SyslogEmergency map to FATAL and levels above SyslogDebug to
+// DEBUG.
+func SeverityFromSyslog(level int) SeverityNumber {
+	if level < SyslogEmergency {
+		level = SyslogEmergency
+	}
+	if level > SyslogDebug {
+		level = SyslogDebug
+	}
+	return syslogSeverities[level]
+}
+
+// Syslog returns the syslog severity level closest to s. UNSPECIFIED maps to
+// SyslogInfo.
+func (s SeverityNumber) Syslog() int {
+	switch {
+	case s >= FATAL:
+		return SyslogEmergency
+	case s >= ERROR3:
+		return SyslogAlert
+	case s == ERROR2:
+		return SyslogCritical
+	case s == ERROR:
+		return SyslogError
+	case s >= WARN:
+		return SyslogWarning
+	case s >= INFO2:
+		return SyslogNotice
+	case s == INFO, s == UNSPECIFIED:
+		return SyslogInfo
+	default:
+		return SyslogDebug
+	}
+}
+
+// SeverityFromSlog returns the severity number of a log/slog level. The
+// offsets between slog levels are kept, so slog.LevelInfo+2 becomes INFO3,
+// and the result is clamped to TRACE and FATAL4.
+func SeverityFromSlog(level slog.Level) SeverityNumber {
+	return clampSeverity(int64(level) + int64(INFO))
+}
+
+// Slog returns the log/slog level of s. UNSPECIFIED maps to slog.LevelInfo.
+func (s SeverityNumber) Slog() slog.Level {
+	if s == UNSPECIFIED {
+		return slog.LevelInfo
+	}
+	return slog.Level(s - INFO)
+}
+
+// Levels of go.uber.org/zap/zapcore.Level.
+const (
+	zapDebug int8 = iota - 1
+	zapInfo
+	zapWarn
+	zapError
+	zapDPanic
+	zapPanic
+	zapFatal
+)
+
+// SeverityFromZap returns the severity number of a go.uber.org/zap level,
+// passed as the int8 value of its zapcore.Level. DPanic maps to FATAL, Panic
+// to FATAL2 and Fatal to FATAL3. Levels below Debug map to TRACE and levels
+// above Fatal to FATAL4.
+func SeverityFromZap(level int8) SeverityNumber {
+	switch {
+	case level < zapDebug:
+		return TRACE
+	case level == zapDebug:
+		return DEBUG
+	case level == zapInfo:
+		return INFO
+	case level == zapWarn:
+		return WARN
+	case level == zapError:
+		return ERROR
+	case level == zapDPanic:
+		return FATAL
+	case level == zapPanic:
+		return FATAL2
+	case level == zapFatal:
+		return FATAL3
+	default:
+		return FATAL4
+	}
+}
+
+// Zap returns the go.uber.org/zap level of s as the int8 value of a
+// zapcore.Level. UNSPECIFIED maps to Info.
+func (s SeverityNumber) Zap() int8 {
+	switch {
+	case s == UNSPECIFIED:
+		return zapInfo
+	case s < INFO:
+		return zapDebug
+	case s < WARN:
+		return zapInfo
+	case s < ERROR:
+		return zapWarn
+	case s < FATAL:
+		return zapError
+	case s == FATAL:
+		return zapDPanic
+	case s == FATAL2:
+		return zapPanic
+	default:
+		return zapFatal
+	}
+}
+
+// Levels of github.com/sirupsen/logrus.Level.
+const (
+	logrusPanic uint32 = iota
+	logrusFatal
+	logrusError
+	logrusWarn
+	logrusInfo
+	logrusDebug
+	logrusTrace
+)
+
+// logrusSeverities maps logrus levels onto severity numbers.
+var logrusSeverities = [...]SeverityNumber{
+	logrusPanic: FATAL2,
+	logrusFatal: FATAL3,
+	logrusError: ERROR,
+	logrusWarn:  WARN,
+	logrusInfo:  INFO,
+	logrusDebug: DEBUG,
+	logrusTrace: TRACE,
+}
+
+// SeverityFromLogrus returns the severity number of a
+// github.com/sirupsen/logrus level, passed as the uint32 value of its
+// logrus.Level. Panic maps to FATAL2 and Fatal to FATAL3, levels above Trace
+// map to TRACE.
+func SeverityFromLogrus(level uint32) SeverityNumber {
+	if level > logrusTrace {
+		return TRACE
+	}
+	return logrusSeverities[level]
+}
+
+// Logrus returns the github.com/sirupsen/logrus level of s as the uint32
+// value of a logrus.Level. UNSPECIFIED maps to Info.
+func (s SeverityNumber) Logrus() uint32 {
+	switch {
+	case s == UNSPECIFIED:
+		return logrusInfo
+	case s < DEBUG:
+		return logrusTrace
+	case s < INFO:
+		return logrusDebug
+	case s < WARN:
+		return logrusInfo
+	case s < ERROR:
+		return logrusWarn
+	case s < FATAL:
+		return logrusError
+	case s == FATAL2:
+		return logrusPanic
+	default:
+		return logrusFatal
+	}
+}
+
+// Levels of github.com/rs/zerolog.Level.
+const (
+	zerologTrace int8 = iota - 1
+	zerologDebug
+	zerologInfo
+	zerologWarn
+	zerologError
+	zerologFatal
+	zerologPanic
+)
+
+// SeverityFromZerolog returns the severity number of a github.com/rs/zerolog
+// level, passed as the int8 value of its zerolog.Level. Fatal maps to FATAL3
+// and Panic to FATAL2. NoLevel, Disabled and undefined levels map to
+// UNSPECIFIED.
+func SeverityFromZerolog(level int8) SeverityNumber {
+	switch level {
+	case zerologTrace:
+		return TRACE
+	case zerologDebug:
+		return DEBUG
+	case zerologInfo:
+		return INFO
+	case zerologWarn:
+		return WARN
+	case zerologError:
+		return ERROR
+	case zerologFatal:
+		return FATAL3
+	case zerologPanic:
+		return FATAL2
+	default:
+		return UNSPECIFIED
+	}
+}
+
+// Zerolog returns the github.com/rs/zerolog level of s as the int8 value of a
+// zerolog.Level. UNSPECIFIED maps to Info.
+func (s SeverityNumber) Zerolog() int8 {
+	switch {
+	case s == UNSPECIFIED:
+		return zerologInfo
+	case s < DEBUG:
+		return zerologTrace
+	case s < INFO:
+		return zerologDebug
+	case s < WARN:
+		return zerologInfo
+	case s < ERROR:
+		return zerologWarn
+	case s < FATAL:
+		return zerologError
+	case s == FATAL2:
+		return zerologPanic
+	default:
+		return zerologFatal
+	}
+}
+
+// clampSeverity returns s limited to the TRACE to FATAL4 range.
+func clampSeverity(s int64) SeverityNumber {
+	if s < int64(TRACE) {
+		return TRACE
+	}
+	if s > int64(FATAL4) {
+		return FATAL4
+	}
+	return SeverityNumber(s)
+}
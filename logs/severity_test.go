@@ -0,0 +1,173 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"testing"
+)
+
+func TestSeverityNames(t *testing.T) {
+	for _, test := range []struct {
+		severity  SeverityNumber
+		str       string
+		name      string
+		shortName string
+	}{
+		{UNSPECIFIED, "UNSPECIFIED", "UNSPECIFIED", "UNS"},
+		{TRACE, "TRACE", "TRACE", "TRC"},
+		{DEBUG4, "DEBUG4", "DEBUG", "DBG"},
+		{INFO, "INFO", "INFO", "INF"},
+		{INFO2, "INFO2", "INFO", "INF"},
+		{WARN3, "WARN3", "WARN", "WRN"},
+		{ERROR, "ERROR", "ERROR", "ERR"},
+		{FATAL4, "FATAL4", "FATAL", "FTL"},
+		{SeverityNumber(25), "SeverityNumber(25)", "UNSPECIFIED", "UNS"},
+	} {
+		assert.Equal(t, test.str, test.severity.String())
+		assert.Equal(t, test.name, test.severity.Name(), test.str)
+		assert.Equal(t, test.shortName, test.severity.ShortName(), test.str)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for s := TRACE; s <= FATAL4; s++ {
+		parsed, err := ParseSeverity(s.String())
+		require.NoError(t, err)
+		assert.Equal(t, s, parsed)
+	}
+
+	for text, severity := range map[string]SeverityNumber{
+		"warning":  WARN,
+		" Err ":    ERROR,
+		"crit":     ERROR2,
+		"notice":   INFO2,
+		"wrn":      WARN,
+		"emerg":    FATAL,
+		"panic":    FATAL2,
+		"debug3":   DEBUG3,
+		"critical": ERROR2,
+	} {
+		parsed, err := ParseSeverity(text)
+		require.NoError(t, err, text)
+		assert.Equal(t, severity, parsed, text)
+	}
+
+	for _, text := range []string{"", "verbose", "INFO5", "INFO1", "2"} {
+		_, err := ParseSeverity(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestSeverityRanges(t *testing.T) {
+	assert.True(t, TRACE4.IsTrace())
+	assert.True(t, DEBUG.IsDebug())
+	assert.True(t, INFO3.IsInfo())
+	assert.True(t, WARN.IsWarn())
+	assert.True(t, ERROR4.IsError())
+	assert.True(t, FATAL.IsFatal())
+	assert.False(t, WARN4.IsError())
+	assert.False(t, FATAL.IsError())
+	assert.False(t, UNSPECIFIED.IsTrace())
+	assert.False(t, UNSPECIFIED.Valid())
+	assert.True(t, FATAL4.Valid())
+}
+
+func TestSeveritySyslog(t *testing.T) {
+	for level, severity := range []SeverityNumber{FATAL, ERROR3, ERROR2, ERROR, WARN, INFO2, INFO, DEBUG} {
+		assert.Equal(t, severity, SeverityFromSyslog(level), level)
+		assert.Equal(t, level, severity.Syslog(), level)
+	}
+	assert.Equal(t, FATAL, SeverityFromSyslog(-1))
+	assert.Equal(t, DEBUG, SeverityFromSyslog(9))
+	assert.Equal(t, SyslogDebug, TRACE.Syslog())
+	assert.Equal(t, SyslogEmergency, FATAL4.Syslog())
+	assert.Equal(t, SyslogInfo, UNSPECIFIED.Syslog())
+}
+
+func TestSeveritySlog(t *testing.T) {
+	for _, test := range []struct {
+		level    slog.Level
+		severity SeverityNumber
+	}{
+		{slog.LevelDebug - 8, TRACE},
+		{slog.LevelDebug - 4, TRACE},
+		{slog.LevelDebug, DEBUG},
+		{slog.LevelInfo, INFO},
+		{slog.LevelInfo + 2, INFO3},
+		{slog.LevelWarn, WARN},
+		{slog.LevelError, ERROR},
+		{slog.LevelError + 4, FATAL},
+		{slog.LevelError + 100, FATAL4},
+	} {
+		assert.Equal(t, test.severity, SeverityFromSlog(test.level), test.level.String())
+	}
+	for s := TRACE; s <= FATAL4; s++ {
+		assert.Equal(t, s, SeverityFromSlog(s.Slog()), s.String())
+	}
+	assert.Equal(t, slog.LevelInfo, UNSPECIFIED.Slog())
+}
+
+func TestSeverityZap(t *testing.T) {
+	// zapcore.DebugLevel is -1, zapcore.FatalLevel is 5.
+	for _, test := range []struct {
+		level    int8
+		severity SeverityNumber
+	}{
+		{-2, TRACE},
+		{-1, DEBUG},
+		{0, INFO},
+		{1, WARN},
+		{2, ERROR},
+		{3, FATAL},
+		{4, FATAL2},
+		{5, FATAL3},
+		{6, FATAL4},
+	} {
+		assert.Equal(t, test.severity, SeverityFromZap(test.level), test.level)
+	}
+	for _, level := range []int8{-1, 0, 1, 2, 3, 4, 5} {
+		assert.Equal(t, level, SeverityFromZap(level).Zap(), level)
+	}
+	assert.Equal(t, int8(-1), TRACE.Zap())
+	assert.Equal(t, int8(0), UNSPECIFIED.Zap())
+}
+
+func TestSeverityLogrus(t *testing.T) {
+	// logrus.PanicLevel is 0, logrus.TraceLevel is 6.
+	for level, severity := range []SeverityNumber{FATAL2, FATAL3, ERROR, WARN, INFO, DEBUG, TRACE} {
+		assert.Equal(t, severity, SeverityFromLogrus(uint32(level)), level)
+		assert.Equal(t, uint32(level), severity.Logrus(), level)
+	}
+	assert.Equal(t, TRACE, SeverityFromLogrus(7))
+	assert.Equal(t, uint32(1), FATAL.Logrus())
+	assert.Equal(t, uint32(4), UNSPECIFIED.Logrus())
+}
+
+func TestSeverityZerolog(t *testing.T) {
+	// zerolog.TraceLevel is -1, zerolog.PanicLevel is 5.
+	for i, severity := range []SeverityNumber{TRACE, DEBUG, INFO, WARN, ERROR, FATAL3, FATAL2} {
+		level := int8(i - 1)
+		assert.Equal(t, severity, SeverityFromZerolog(level), level)
+		assert.Equal(t, level, severity.Zerolog(), level)
+	}
+	assert.Equal(t, UNSPECIFIED, SeverityFromZerolog(6))
+	assert.Equal(t, UNSPECIFIED, SeverityFromZerolog(7))
+	assert.Equal(t, int8(1), UNSPECIFIED.Zerolog())
+}
@@ -0,0 +1,525 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the kind of a Value.
+type Kind int
+
+const (
+	// KindEmpty is the Kind of an empty Value.
+	KindEmpty Kind = iota
+	KindBool
+	KindFloat64
+	KindInt64
+	KindString
+	KindBytes
+	KindSlice
+	KindMap
+)
+
+var kindNames = [...]string{
+	KindEmpty:   "Empty",
+	KindBool:    "Bool",
+	KindFloat64: "Float64",
+	KindInt64:   "Int64",
+	KindString:  "String",
+	KindBytes:   "Bytes",
+	KindSlice:   "Slice",
+	KindMap:     "Map",
+}
+
+// String returns the name of k.
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Value is the value of a log record body, see
+// https://opentelemetry.io/docs/specs/otel/logs/data-model/#type-any
+//
+// The zero Value is empty.
+type Value struct {
+	kind Kind
+	// num holds the bits of bool, int64 and float64 values.
+	num uint64
+	str string
+	// any holds []byte, []Value and []KeyValue values.
+	any any
+}
+
+// KeyValue is a key and Value pair of a map Value.
+type KeyValue struct {
+	Key   string
+	Value Value
+}
+
+// StringValue returns a Value for a string.
+func StringValue(v string) Value {
+	return Value{kind: KindString, str: v}
+}
+
+// IntValue returns a Value for an int.
+func IntValue(v int) Value {
+	return Int64Value(int64(v))
+}
+
+// Int64Value returns a Value for an int64.
+func Int64Value(v int64) Value {
+	return Value{kind: KindInt64, num: uint64(v)}
+}
+
+// Float64Value returns a Value for a float64.
+func Float64Value(v float64) Value {
+	return Value{kind: KindFloat64, num: math.Float64bits(v)}
+}
+
+// BoolValue returns a Value for a bool.
+func BoolValue(v bool) Value {
+	var num uint64
+	if v {
+		num = 1
+	}
+	return Value{kind: KindBool, num: num}
+}
+
+// BytesValue returns a Value for a []byte. The slice is not copied and must
+// not be modified afterwards.
+func BytesValue(v []byte) Value {
+	return Value{kind: KindBytes, any: v}
+}
+
+// SliceValue returns a Value for a slice of Values. The slice is not copied
+// and must not be modified afterwards.
+func SliceValue(vs ...Value) Value {
+	return Value{kind: KindSlice, any: vs}
+}
+
+// MapValue returns a Value for a map of KeyValues. The slice is not copied
+// and must not be modified afterwards.
+func MapValue(kvs ...KeyValue) Value {
+	return Value{kind: KindMap, any: kvs}
+}
+
+// String returns a KeyValue for a string value.
+func String(key, value string) KeyValue {
+	return KeyValue{Key: key, Value: StringValue(value)}
+}
+
+// Int returns a KeyValue for an int value.
+func Int(key string, value int) KeyValue {
+	return KeyValue{Key: key, Value: IntValue(value)}
+}
+
+// Int64 returns a KeyValue for an int64 value.
+func Int64(key string, value int64) KeyValue {
+	return KeyValue{Key: key, Value: Int64Value(value)}
+}
+
+// Float64 returns a KeyValue for a float64 value.
+func Float64(key string, value float64) KeyValue {
+	return KeyValue{Key: key, Value: Float64Value(value)}
+}
+
+// Bool returns a KeyValue for a bool value.
+func Bool(key string, value bool) KeyValue {
+	return KeyValue{Key: key, Value: BoolValue(value)}
+}
+
+// Bytes returns a KeyValue for a []byte value.
+func Bytes(key string, value []byte) KeyValue {
+	return KeyValue{Key: key, Value: BytesValue(value)}
+}
+
+// Slice returns a KeyValue for a slice value.
+func Slice(key string, values ...Value) KeyValue {
+	return KeyValue{Key: key, Value: SliceValue(values...)}
+}
+
+// Map returns a KeyValue for a map value.
+func Map(key string, values ...KeyValue) KeyValue {
+	return KeyValue{Key: key, Value: MapValue(values...)}
+}
+
+// Kind returns the Kind of v.
+func (v Value) Kind() Kind {
+	return v.kind
+}
+
+// Empty reports whether v is empty.
+func (v Value) Empty() bool {
+	return v.kind == KindEmpty
+}
+
+// AsString returns the string of v, or "" if v is not of KindString.
+func (v Value) AsString() string {
+	return v.str
+}
+
+// AsInt64 returns the int64 of v, or 0 if v is not of KindInt64.
+func (v Value) AsInt64() int64 {
+	if v.kind != KindInt64 {
+		return 0
+	}
+	return int64(v.num)
+}
+
+// AsFloat64 returns the float64 of v, or 0 if v is not of KindFloat64.
+func (v Value) AsFloat64() float64 {
+	if v.kind != KindFloat64 {
+		return 0
+	}
+	return math.Float64frombits(v.num)
+}
+
+// AsBool returns the bool of v, or false if v is not of KindBool.
+func (v Value) AsBool() bool {
+	return v.kind == KindBool && v.num == 1
+}
+
+// AsBytes returns the []byte of v, or nil if v is not of KindBytes.
+func (v Value) AsBytes() []byte {
+	b, _ := v.any.([]byte)
+	return b
+}
+
+// AsSlice returns the Values of v, or nil if v is not of KindSlice.
+func (v Value) AsSlice() []Value {
+	s, _ := v.any.([]Value)
+	return s
+}
+
+// AsMap returns the KeyValues of v, or nil if v is not of KindMap.
+func (v Value) AsMap() []KeyValue {
+	m, _ := v.any.([]KeyValue)
+	return m
+}
+
+// Any returns v as a Go value: nil, bool, int64, float64, string, []byte,
+// []any or map[string]any.
+func (v Value) Any() any {
+	switch v.kind {
+	case KindBool:
+		return v.AsBool()
+	case KindInt64:
+		return v.AsInt64()
+	case KindFloat64:
+		return v.AsFloat64()
+	case KindString:
+		return v.str
+	case KindBytes:
+		return v.AsBytes()
+	case KindSlice:
+		s := v.AsSlice()
+		out := make([]any, len(s))
+		for i, elem := range s {
+			out[i] = elem.Any()
+		}
+		return out
+	case KindMap:
+		m := v.AsMap()
+		out := make(map[string]any, len(m))
+		for _, kv := range m {
+			out[kv.Key] = kv.Value.Any()
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// Equal reports whether v and w hold the same value.
+func (v Value) Equal(w Value) bool {
+	if v.kind != w.kind {
+		return false
+	}
+	switch v.kind {
+	case KindString:
+		return v.str == w.str
+	case KindBytes:
+		return bytes.Equal(v.AsBytes(), w.AsBytes())
+	case KindSlice:
+		s, t := v.AsSlice(), w.AsSlice()
+		if len(s) != len(t) {
+			return false
+		}
+		for i := range s {
+			if !s[i].Equal(t[i]) {
+				return false
+			}
+		}
+		return true
+	case KindMap:
+		m, n := v.AsMap(), w.AsMap()
+		if len(m) != len(n) {
+			return false
+		}
+		for i := range m {
+			if m[i].Key != n[i].Key || !m[i].Value.Equal(n[i].Value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return v.num == w.num
+	}
+}
+
+// String returns v formatted as text. Bytes are base64 encoded, slices are
+// formatted as "[a b]" and maps as "{k1:v1 k2:v2}". An empty Value is "".
+func (v Value) String() string {
+	var sb strings.Builder
+	v.write(&sb)
+	return sb.String()
+}
+
+func (v Value) write(sb *strings.Builder) {
+	switch v.kind {
+	case KindBool:
+		sb.WriteString(strconv.FormatBool(v.AsBool()))
+	case KindInt64:
+		sb.WriteString(strconv.FormatInt(v.AsInt64(), 10))
+	case KindFloat64:
+		sb.WriteString(strconv.FormatFloat(v.AsFloat64(), 'g', -1, 64))
+	case KindString:
+		sb.WriteString(v.str)
+	case KindBytes:
+		sb.WriteString(base64.StdEncoding.EncodeToString(v.AsBytes()))
+	case KindSlice:
+		sb.WriteByte('[')
+		for i, elem := range v.AsSlice() {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			elem.write(sb)
+		}
+		sb.WriteByte(']')
+	case KindMap:
+		sb.WriteByte('{')
+		for i, kv := range v.AsMap() {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(kv.Key)
+			sb.WriteByte(':')
+			kv.Value.write(sb)
+		}
+		sb.WriteByte('}')
+	}
+}
+
+// ValueOf returns the Value of a Go value. It is the compatibility path for
+// bodies passed as any, prefer the typed constructors such as StringValue.
+//
+// Pointers are dereferenced, unsigned integers above math.MaxInt64 are
+// clamped, times are formatted as RFC 3339 strings, errors become their
+// message, maps are sorted by key and structs become maps of their exported
+// fields, named by their json tag if any, unless they are a fmt.Stringer.
+// Nil values, zero times and empty slices become an empty Value, and empty
+// Values are left out of maps. So are the values nested more than 32 levels
+// deep and the pointers back to a value that is being converted.
+func ValueOf(v any) Value {
+	switch val := v.(type) {
+	case nil:
+		return Value{}
+	case Value:
+		return val
+	case string:
+		return StringValue(val)
+	case *string:
+		if val == nil {
+			return Value{}
+		}
+		return StringValue(*val)
+	case bool:
+		return BoolValue(val)
+	case int:
+		return IntValue(val)
+	case int64:
+		return Int64Value(val)
+	case float64:
+		return Float64Value(val)
+	case []Value:
+		return SliceValue(val...)
+	case []KeyValue:
+		return MapValue(val...)
+	case error:
+		return StringValue(val.Error())
+	}
+	return reflectValue(reflect.ValueOf(v), nil)
+}
+
+// maxValueDepth is the maximum number of levels of pointers, interfaces,
+// slices, maps and structs that ValueOf follows.
+const maxValueDepth = 32
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	valueType = reflect.TypeOf(Value{})
+)
+
+// reflectValue returns the Value of the Go value val of a type that ValueOf
+// does not handle directly. path holds the values val is nested in, the
+// pointers among them are followed to detect cycles.
+func reflectValue(val reflect.Value, path []reflect.Value) Value {
+	switch val.Kind() {
+	case reflect.Invalid:
+		return Value{}
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.UnsafePointer,
+		reflect.Interface, reflect.Slice:
+		if val.IsNil() {
+			return Value{}
+		}
+	}
+	if len(path) >= maxValueDepth || !val.CanInterface() {
+		return Value{}
+	}
+	if val.Kind() == reflect.Pointer {
+		for _, p := range path {
+			if p.Kind() == reflect.Pointer && p.Pointer() == val.Pointer() && p.Type() == val.Type() {
+				return Value{}
+			}
+		}
+	}
+	typ := val.Type()
+	if typ == valueType {
+		return val.Interface().(Value)
+	}
+	switch v := val.Interface().(type) {
+	case error:
+		return StringValue(v.Error())
+	case fmt.Stringer:
+		// Stringers of kinds that have a Value of their own, such as
+		// time.Duration, keep it.
+		if isComposite(typ) {
+			return StringValue(v.String())
+		}
+	}
+	path = append(path, val)
+	if val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		// Every level of pointers and interfaces is unwrapped.
+		return reflectValue(val.Elem(), path)
+	}
+
+	switch {
+	case val.CanFloat():
+		return Float64Value(val.Float())
+	case val.CanInt():
+		return Int64Value(val.Int())
+	case val.Kind() == reflect.Bool:
+		return BoolValue(val.Bool())
+	case val.CanUint():
+		u := val.Uint()
+		if u > math.MaxInt64 {
+			u = math.MaxInt64
+		}
+		return Int64Value(int64(u))
+	case val.Kind() == reflect.String:
+		return StringValue(val.String())
+	case typ.ConvertibleTo(timeType):
+		t := val.Convert(timeType).Interface().(time.Time)
+		if t.IsZero() {
+			return Value{}
+		}
+		return StringValue(t.Format(time.RFC3339Nano))
+	case (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) && typ.Elem().Kind() == reflect.Uint8:
+		if val.Len() == 0 {
+			return Value{}
+		}
+		b := make([]byte, val.Len())
+		reflect.Copy(reflect.ValueOf(b), val)
+		return BytesValue(b)
+	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
+		if val.Len() == 0 {
+			return Value{}
+		}
+		elems := make([]Value, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			elems = append(elems, reflectValue(val.Index(i), path))
+		}
+		return SliceValue(elems...)
+	case typ.Kind() == reflect.Map:
+		keys := val.MapKeys()
+		kvs := make([]KeyValue, 0, len(keys))
+		for _, key := range keys {
+			elem := reflectValue(val.MapIndex(key), path)
+			if elem.Empty() {
+				continue
+			}
+			kvs = append(kvs, KeyValue{Key: fmt.Sprint(key.Interface()), Value: elem})
+		}
+		sort.SliceStable(kvs, func(i, j int) bool {
+			return kvs[i].Key < kvs[j].Key
+		})
+		return MapValue(kvs...)
+	case typ.Kind() == reflect.Struct:
+		kvs := make([]KeyValue, 0, typ.NumField())
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				tagName, _, _ := strings.Cut(tag, ",")
+				if tagName == "-" {
+					continue
+				}
+				if tagName != "" {
+					name = tagName
+				}
+			}
+			elem := reflectValue(val.Field(i), path)
+			if elem.Empty() {
+				continue
+			}
+			kvs = append(kvs, KeyValue{Key: name, Value: elem})
+		}
+		return MapValue(kvs...)
+	default:
+		return StringValue(fmt.Sprint(val.Interface()))
+	}
+}
+
+// isComposite reports whether the values of typ are pointers to, or are,
+// structs other than times, maps, slices or arrays.
+func isComposite(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.ConvertibleTo(timeType) {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestValueKinds(t *testing.T) {
+	assert.True(t, Value{}.Empty())
+	assert.Equal(t, KindEmpty, Value{}.Kind())
+	assert.Nil(t, Value{}.Any())
+
+	assert.Equal(t, "hello", StringValue("hello").AsString())
+	assert.Equal(t, int64(-3), IntValue(-3).AsInt64())
+	assert.Equal(t, 2.5, Float64Value(2.5).AsFloat64())
+	assert.True(t, BoolValue(true).AsBool())
+	assert.False(t, BoolValue(false).AsBool())
+	assert.Equal(t, []byte{1, 2}, BytesValue([]byte{1, 2}).AsBytes())
+	assert.Equal(t, []Value{IntValue(1)}, SliceValue(IntValue(1)).AsSlice())
+	assert.Equal(t, []KeyValue{Int("a", 1)}, MapValue(Int("a", 1)).AsMap())
+
+	// Accessors of another kind return the zero value.
+	assert.Equal(t, "", IntValue(1).AsString())
+	assert.Equal(t, int64(0), Float64Value(1).AsInt64())
+	assert.Equal(t, float64(0), IntValue(1).AsFloat64())
+	assert.False(t, IntValue(1).AsBool())
+	assert.Nil(t, StringValue("a").AsSlice())
+
+	assert.Equal(t, "Map", KindMap.String())
+	assert.Equal(t, "Kind(42)", Kind(42).String())
+}
+
+func TestValueAny(t *testing.T) {
+	v := MapValue(
+		String("name", "api"),
+		Slice("ports", IntValue(80), Float64Value(1.5), BoolValue(true)),
+		Map("nested", Bytes("raw", []byte("x"))),
+	)
+	assert.Equal(t, map[string]any{
+		"name":   "api",
+		"ports":  []any{int64(80), 1.5, true},
+		"nested": map[string]any{"raw": []byte("x")},
+	}, v.Any())
+}
+
+func TestValueString(t *testing.T) {
+	v := MapValue(
+		String("name", "api"),
+		Slice("ports", IntValue(80), Float64Value(1.5)),
+		Bool("ok", true),
+		Bytes("raw", []byte("hi")),
+	)
+	assert.Equal(t, "{name:api ports:[80 1.5] ok:true raw:aGk=}", v.String())
+	assert.Equal(t, "", Value{}.String())
+}
+
+func TestValueEqual(t *testing.T) {
+	a := MapValue(String("k", "v"), Slice("s", IntValue(1)))
+	b := MapValue(String("k", "v"), Slice("s", IntValue(1)))
+	assert.True(t, a.Equal(b))
+	assert.False(t, a.Equal(MapValue(String("k", "v"))))
+	assert.False(t, IntValue(1).Equal(Float64Value(1)))
+	assert.True(t, BytesValue([]byte{1}).Equal(BytesValue([]byte{1})))
+	assert.True(t, Value{}.Equal(Value{}))
+}
+
+type valueOfStruct struct {
+	Name    string `json:"name,omitempty"`
+	Count   uint64
+	Skipped string `json:"-"`
+	Created time.Time
+	Tags    []string
+	Parent  *valueOfStruct
+	private int
+}
+
+type valueOfPoint struct {
+	A int
+}
+
+type valueOfNode struct {
+	Name string
+	Next *valueOfNode
+}
+
+type valueOfStringer struct {
+	id int
+}
+
+func (s valueOfStringer) String() string { return fmt.Sprintf("id-%d", s.id) }
+
+func TestValueOfDepth(t *testing.T) {
+	deep := any(1)
+	for i := 0; i < 2*maxValueDepth; i++ {
+		deep = []any{deep}
+	}
+
+	v := ValueOf(deep)
+	depth := 0
+	for v.Kind() == KindSlice {
+		require.Len(t, v.AsSlice(), 1)
+		v = v.AsSlice()[0]
+		depth++
+	}
+	assert.True(t, v.Empty())
+	assert.LessOrEqual(t, depth, maxValueDepth)
+}
+
+func TestValueOf(t *testing.T) {
+	str := "text"
+	n := 7
+	pn := &n
+	point := &valueOfPoint{A: 1}
+	self := &valueOfNode{Name: "self"}
+	self.Next = self
+	parent := &valueOfNode{Name: "parent"}
+	parent.Next = &valueOfNode{Name: "child", Next: parent}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, test := range []struct {
+		name string
+		in   any
+		want Value
+	}{
+		{"nil", nil, Value{}},
+		{"Value", IntValue(1), IntValue(1)},
+		{"string", "text", StringValue("text")},
+		{"*string", &str, StringValue("text")},
+		{"nil *string", (*string)(nil), Value{}},
+		{"int8", int8(-2), Int64Value(-2)},
+		{"uint max", uint64(math.MaxUint64), Int64Value(math.MaxInt64)},
+		{"float32", float32(0.5), Float64Value(0.5)},
+		{"bytes", []byte{1}, BytesValue([]byte{1})},
+		{"byte array", [2]byte{1, 2}, BytesValue([]byte{1, 2})},
+		{"empty slice", []int{}, Value{}},
+		{"time", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), StringValue("2024-01-02T03:04:05Z")},
+		{"zero time", time.Time{}, Value{}},
+		{"slice", []any{"a", 1, nil}, SliceValue(StringValue("a"), IntValue(1), Value{})},
+		{"map", map[string]any{"b": 2, "a": "x", "nil": nil}, MapValue(String("a", "x"), Int("b", 2))},
+		{"int keys", map[int]bool{2: true, 1: false}, MapValue(Bool("1", false), Bool("2", true))},
+		{"pointer in map", map[string]any{"p": point, "n": &n}, MapValue(Int64("n", 7), Map("p", Int64("A", 1)))},
+		{"pointer in slice", []any{point, &n}, SliceValue(MapValue(Int64("A", 1)), Int64Value(7))},
+		{"pointer in interface field", struct{ X any }{point}, MapValue(Map("X", Int64("A", 1)))},
+		{"pointer to pointer", &pn, Int64Value(7)},
+		{"nil pointer in map", map[string]any{"p": (*valueOfPoint)(nil)}, MapValue()},
+		{"self reference", self, MapValue(String("Name", "self"))},
+		{"cycle", parent, MapValue(String("Name", "parent"), Map("Next", String("Name", "child")))},
+		{"error", errors.New("boom"), StringValue("boom")},
+		{"error in map", map[string]any{"err": fmt.Errorf("wrap: %w", errors.New("boom"))}, MapValue(String("err", "wrap: boom"))},
+		{"stringer", valueOfStringer{id: 1}, StringValue("id-1")},
+		{"stringer pointer in slice", []any{&valueOfStringer{id: 2}}, SliceValue(StringValue("id-2"))},
+		{"duration", time.Second, Int64Value(int64(time.Second))},
+		{"time pointer", &at, StringValue("2024-01-02T03:04:05Z")},
+		{
+			"struct",
+			&valueOfStruct{Name: "n", Count: 3, Skipped: "s", Tags: []string{"t"}, Parent: &valueOfStruct{}},
+			MapValue(
+				String("name", "n"),
+				Int64("Count", 3),
+				Slice("Tags", StringValue("t")),
+				Map("Parent", String("name", ""), Int64("Count", 0)),
+			),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := ValueOf(test.in)
+			assert.True(t, test.want.Equal(got), "want %s, got %s", test.want, got)
+		})
+	}
+}
@@ -35,6 +35,17 @@ const (
 	// 512). Note: it must be less than or equal to
 	// EnvBatchLogsProcessorMaxQueueSize.
 	BatchLogsProcessorMaxExportBatchSizeKey = "OTEL_BLRP_MAX_EXPORT_BATCH_SIZE"
+
+	// AttributeValueLengthKey is the maximum allowed attribute value size.
+	AttributeValueLengthKey = "OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT"
+	// AttributeCountKey is the maximum allowed attribute count.
+	AttributeCountKey = "OTEL_ATTRIBUTE_COUNT_LIMIT"
+	// LogRecordAttributeValueLengthKey is the maximum allowed attribute value
+	// size for a log record.
+	LogRecordAttributeValueLengthKey = "OTEL_LOGRECORD_ATTRIBUTE_VALUE_LENGTH_LIMIT"
+	// LogRecordAttributeCountKey is the maximum allowed attribute count for a
+	// log record.
+	LogRecordAttributeCountKey = "OTEL_LOGRECORD_ATTRIBUTE_COUNT_LIMIT"
 )
 
 // firstInt returns the value of the first matching environment variable from
@@ -103,3 +114,19 @@ func BatchLogsProcessorMaxQueueSize(defaultValue int) int {
 func BatchLogsProcessorMaxExportBatchSize(defaultValue int) int {
 	return IntEnvOr(BatchLogsProcessorMaxExportBatchSizeKey, defaultValue)
 }
+
+// LogRecordAttributeValueLength returns the environment variable value for the
+// OTEL_LOGRECORD_ATTRIBUTE_VALUE_LENGTH_LIMIT key if it exists, otherwise the
+// value of OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT if it exists, otherwise
+// defaultValue is returned.
+func LogRecordAttributeValueLength(defaultValue int) int {
+	return firstInt(defaultValue, LogRecordAttributeValueLengthKey, AttributeValueLengthKey)
+}
+
+// LogRecordAttributeCount returns the environment variable value for the
+// OTEL_LOGRECORD_ATTRIBUTE_COUNT_LIMIT key if it exists, otherwise the value
+// of OTEL_ATTRIBUTE_COUNT_LIMIT if it exists, otherwise defaultValue is
+// returned.
+func LogRecordAttributeCount(defaultValue int) int {
+	return firstInt(defaultValue, LogRecordAttributeCountKey, AttributeCountKey)
+}
@@ -212,6 +212,11 @@ func (lrp *batchLogRecordProcessor) OnEmit(rol ReadableLogRecord) {
 		return
 	}
 
+	// Processors registered after this one may still modify the record.
+	if elr, ok := rol.(*exportableLogRecord); ok {
+		rol = elr.clone()
+	}
+
 	lrp.enqueue(rol)
 }
 
@@ -0,0 +1,314 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	"hash/maphash"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Attribute keys of the summary log records of a DedupProcessor.
+const (
+	// RepeatCountKey is the number of duplicates the summary stands for.
+	RepeatCountKey = attribute.Key("log.record.repeat_count")
+	// FirstTimestampKey is the time of the first occurrence, in RFC 3339
+	// format.
+	FirstTimestampKey = attribute.Key("log.record.first_timestamp")
+	// LastTimestampKey is the time of the last duplicate, in RFC 3339 format.
+	LastTimestampKey = attribute.Key("log.record.last_timestamp")
+)
+
+// DefaultMaxDedupFingerprints is the default maximum number of fingerprints
+// a DedupProcessor tracks.
+const DefaultMaxDedupFingerprints = 4096
+
+// DedupProcessorOption configures a DedupProcessor.
+type DedupProcessorOption func(cfg *dedupConfig)
+
+type dedupConfig struct {
+	keys            []string
+	maxFingerprints int
+}
+
+// WithDedupAttributes adds the values of the attributes with the given keys
+// to the fingerprint of log records, so log records that differ in them are
+// not duplicates. By default the attributes are ignored.
+func WithDedupAttributes(keys ...attribute.Key) DedupProcessorOption {
+	return func(cfg *dedupConfig) {
+		for _, k := range keys {
+			cfg.keys = append(cfg.keys, string(k))
+		}
+	}
+}
+
+// WithMaxDedupFingerprints sets the maximum number of fingerprints that are
+// tracked at the same time. Log records with a new fingerprint are passed on
+// without tracking them while the maximum is reached. The default is
+// DefaultMaxDedupFingerprints.
+func WithMaxDedupFingerprints(max int) DedupProcessorOption {
+	return func(cfg *dedupConfig) {
+		cfg.maxFingerprints = max
+	}
+}
+
+// DedupProcessor is a LogRecordProcessor that collapses duplicate log records
+// before they reach another LogRecordProcessor.
+//
+// Log records are duplicates if they have the same body, severity, event
+// name, instrumentation scope, resource and, see WithDedupAttributes,
+// attribute values. The first log record is passed on and starts a window,
+// the duplicates within the window are held back. When the window ends, a
+// summary log record is passed on if there were duplicates: a copy of the
+// first duplicate with the timestamp of the last one and the RepeatCountKey,
+// FirstTimestampKey and LastTimestampKey attributes.
+//
+// Windows end when they expire, on ForceFlush and on Shutdown, so a
+// DedupProcessor can be put in front of a batching processor.
+type DedupProcessor struct {
+	next   LogRecordProcessor
+	window time.Duration
+	cfg    dedupConfig
+	seed   maphash.Seed
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[uint64]*dedupEntry
+	// seq orders the entries by their first log record.
+	seq uint64
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	stopped  chan struct{}
+}
+
+var _ ContextLogRecordProcessor = (*DedupProcessor)(nil)
+
+type dedupEntry struct {
+	seq uint64
+	// end is when the window ends.
+	end         time.Time
+	first, last time.Time
+	count       int64
+	// record is a copy of the first duplicate, later duplicates only update
+	// count and last.
+	record *exportableLogRecord
+}
+
+// NewDedupProcessor returns a DedupProcessor that passes the log records on to
+// next, collapsing the duplicates within window.
+func NewDedupProcessor(next LogRecordProcessor, window time.Duration, opts ...DedupProcessorOption) *DedupProcessor {
+	cfg := dedupConfig{maxFingerprints: DefaultMaxDedupFingerprints}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p := &DedupProcessor{
+		next:    next,
+		window:  window,
+		cfg:     cfg,
+		seed:    maphash.MakeSeed(),
+		now:     time.Now,
+		entries: make(map[uint64]*dedupEntry),
+		stopCh:  make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if window > 0 {
+		go p.expire()
+	} else {
+		close(p.stopped)
+	}
+	return p
+}
+
+// OnEmit passes rol on unless it is a duplicate.
+func (p *DedupProcessor) OnEmit(rol ReadableLogRecord) {
+	p.OnEmitContext(context.Background(), rol)
+}
+
+// OnEmitContext passes rol on within ctx unless it is a duplicate. A summary
+// of the previous window of rol is passed on first, if it expired.
+func (p *DedupProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	if p.window <= 0 {
+		onEmit(ctx, p.next, rol)
+		return
+	}
+	fp := p.fingerprint(rol)
+	ts := rol.ObservedTimestamp()
+	if t := rol.Timestamp(); t != nil {
+		ts = *t
+	}
+
+	p.mu.Lock()
+	now := p.now()
+	var expired *dedupEntry
+	if e, ok := p.entries[fp]; ok {
+		if now.Before(e.end) {
+			if e.record == nil {
+				e.record = copyLogRecord(rol)
+			}
+			e.count++
+			e.last = ts
+			p.mu.Unlock()
+			return
+		}
+		delete(p.entries, fp)
+		expired = e
+	}
+	if len(p.entries) < p.cfg.maxFingerprints {
+		p.seq++
+		p.entries[fp] = &dedupEntry{seq: p.seq, end: now.Add(p.window), first: ts, last: ts}
+	}
+	p.mu.Unlock()
+
+	p.summarize(expired)
+	onEmit(ctx, p.next, rol)
+}
+
+// Shutdown passes the summaries of all windows on and shuts the wrapped
+// processor down.
+func (p *DedupProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stopCh) })
+	select {
+	case <-p.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	p.flush(true)
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush passes the summaries of all windows on and flushes the wrapped
+// processor.
+func (p *DedupProcessor) ForceFlush(ctx context.Context) error {
+	p.flush(true)
+	return p.next.ForceFlush(ctx)
+}
+
+// expire ends the expired windows until the processor is shut down.
+func (p *DedupProcessor) expire() {
+	defer close(p.stopped)
+	ticker := time.NewTicker(p.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.flush(false)
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+// flush ends the expired windows, or all windows if all is set, and passes
+// their summaries on in the order of their first log record.
+func (p *DedupProcessor) flush(all bool) {
+	p.mu.Lock()
+	now := p.now()
+	var ended []*dedupEntry
+	for fp, e := range p.entries {
+		if all || !now.Before(e.end) {
+			delete(p.entries, fp)
+			ended = append(ended, e)
+		}
+	}
+	p.mu.Unlock()
+
+	sort.Slice(ended, func(i, j int) bool { return ended[i].seq < ended[j].seq })
+	for _, e := range ended {
+		p.summarize(e)
+	}
+}
+
+// summarize passes the summary of e on, if it had duplicates.
+func (p *DedupProcessor) summarize(e *dedupEntry) {
+	if e == nil || e.count == 0 {
+		return
+	}
+	r := e.record
+	r.SetTimestamp(e.last)
+	r.SetAttribute(RepeatCountKey.Int64(e.count))
+	r.SetAttribute(FirstTimestampKey.String(e.first.Format(time.RFC3339Nano)))
+	r.SetAttribute(LastTimestampKey.String(e.last.Format(time.RFC3339Nano)))
+	onEmit(context.Background(), p.next, r)
+}
+
+// fingerprint returns the hash of the parts of rol that make up duplicates.
+func (p *DedupProcessor) fingerprint(rol ReadableLogRecord) uint64 {
+	var h maphash.Hash
+	h.SetSeed(p.seed)
+
+	writeDedupValue(&h, rol.BodyValue())
+	if sn := rol.SeverityNumber(); sn != nil {
+		h.WriteByte(byte(*sn))
+	}
+	h.WriteByte(0)
+	if st := rol.SeverityText(); st != nil {
+		h.WriteString(*st)
+	}
+	h.WriteByte(0)
+	h.WriteString(rol.EventName())
+	h.WriteByte(0)
+	if is := rol.InstrumentationScope(); is != nil {
+		h.WriteString(is.Name)
+		h.WriteByte(0)
+		h.WriteString(is.Version)
+	}
+	h.WriteByte(0)
+	if res := rol.Resource(); res != nil {
+		h.WriteString(res.SchemaURL())
+		h.WriteByte(0)
+		h.WriteString(res.Encoded(attribute.DefaultEncoder()))
+	}
+	h.WriteByte(0)
+
+	for _, key := range p.cfg.keys {
+		h.WriteString(key)
+		h.WriteByte(0)
+		if v, ok := dedupAttribute(rol, key); ok {
+			writeDedupValue(&h, v)
+		}
+		h.WriteByte(0)
+	}
+	return h.Sum64()
+}
+
+func writeDedupValue(h *maphash.Hash, v logs.Value) {
+	h.WriteByte(byte(v.Kind()))
+	h.WriteString(v.String())
+	h.WriteByte(0)
+}
+
+// dedupAttribute returns the value of the attribute key of rol.
+func dedupAttribute(rol ReadableLogRecord, key string) (logs.Value, bool) {
+	if attrs := rol.Attributes(); attrs != nil {
+		for _, a := range *attrs {
+			if string(a.Key) == key {
+				return logs.ValueOf(a.Value.AsInterface()), true
+			}
+		}
+	}
+	for _, kv := range rol.StructuredAttributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return logs.Value{}, false
+}
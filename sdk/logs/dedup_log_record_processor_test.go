@@ -0,0 +1,186 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"testing"
+	"time"
+)
+
+func newTestDedupProcessor(next LogRecordProcessor, opts ...DedupProcessorOption) (*DedupProcessor, *time.Time) {
+	p := NewDedupProcessor(next, time.Hour, opts...)
+	now := time.Unix(0, 0)
+	p.now = func() time.Time { return now }
+	return p, &now
+}
+
+func emitMessage(logger logs.Logger, ts time.Time, body string, attrs ...attribute.KeyValue) {
+	sn := logs.ERROR
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		Timestamp:      &ts,
+		SeverityNumber: &sn,
+		BodyValue:      logs.StringValue(body),
+		Attributes:     &attrs,
+	}))
+}
+
+func TestDedupProcessor(t *testing.T) {
+	next := &testProcessor{}
+	processor, now := newTestDedupProcessor(next)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	first := time.Unix(100, 0).UTC()
+
+	emitMessage(logger, first, "retrying")
+	emitMessage(logger, first.Add(time.Second), "retrying")
+	emitMessage(logger, first.Add(2*time.Second), "other")
+	emitMessage(logger, first.Add(3*time.Second), "retrying")
+	assert.Equal(t, []string{"retrying", "other"}, bodies(next.records))
+
+	// The next duplicate after the window starts a new one.
+	*now = now.Add(time.Hour)
+	emitMessage(logger, first.Add(time.Hour), "retrying")
+	require.Equal(t, []string{"retrying", "other", "retrying", "retrying"}, bodies(next.records))
+	summary := next.records[2]
+	assert.Equal(t, first.Add(3*time.Second), *summary.Timestamp())
+	assert.Equal(t, []attribute.KeyValue{
+		RepeatCountKey.Int64(2),
+		FirstTimestampKey.String("1970-01-01T00:01:40Z"),
+		LastTimestampKey.String("1970-01-01T00:01:43Z"),
+	}, *summary.Attributes())
+	assert.Empty(t, *next.records[3].Attributes())
+}
+
+func TestDedupProcessorFingerprint(t *testing.T) {
+	next := &testProcessor{}
+	processor, _ := newTestDedupProcessor(next, WithDedupAttributes("user"))
+	provider := NewLoggerProvider(WithLogRecordProcessor(processor))
+	ts := time.Unix(100, 0)
+
+	emitMessage(provider.Logger("a"), ts, "failed", attribute.String("user", "alice"), attribute.Int("attempt", 1))
+	emitMessage(provider.Logger("a"), ts, "failed", attribute.String("user", "alice"), attribute.Int("attempt", 2))
+	emitMessage(provider.Logger("a"), ts, "failed", attribute.String("user", "bob"))
+	emitMessage(provider.Logger("b"), ts, "failed", attribute.String("user", "alice"))
+	sn := logs.WARN
+	provider.Logger("a").Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		SeverityNumber: &sn,
+		BodyValue:      logs.StringValue("failed"),
+	}))
+	assert.Len(t, next.records, 4)
+}
+
+func TestDedupProcessorEventAndResource(t *testing.T) {
+	next := &testProcessor{}
+	processor, _ := newTestDedupProcessor(next)
+	checkout := NewLoggerProvider(
+		WithLogRecordProcessor(processor),
+		WithResource(resource.NewSchemaless(attribute.String("service.name", "checkout"))),
+	)
+	cart := NewLoggerProvider(
+		WithLogRecordProcessor(processor),
+		WithResource(resource.NewSchemaless(attribute.String("service.name", "cart"))),
+	)
+
+	// Records of different resources behind a shared processor and events
+	// with different names are not duplicates.
+	emitMessage(checkout.Logger("a"), time.Unix(100, 0), "failed")
+	emitMessage(cart.Logger("a"), time.Unix(100, 0), "failed")
+	for _, name := range []string{"payment.failed", "refund.failed", "refund.failed"} {
+		checkout.Logger("a").Emit(logs.NewLogRecord(logs.LogRecordConfig{
+			EventName: name,
+			BodyValue: logs.StringValue("failed"),
+		}))
+	}
+	assert.Len(t, next.records, 4)
+}
+
+func TestDedupProcessorSummaryRecord(t *testing.T) {
+	next := &testProcessor{}
+	processor, _ := newTestDedupProcessor(next)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	ts := time.Unix(100, 0).UTC()
+
+	for i := 1; i <= 4; i++ {
+		emitMessage(logger, ts.Add(time.Duration(i)*time.Second), "retrying", attribute.Int("attempt", i))
+	}
+	require.NoError(t, processor.ForceFlush(context.Background()))
+
+	// The summary is the first duplicate with the timestamp of the last one.
+	require.Len(t, next.records, 2)
+	summary := next.records[1]
+	assert.Equal(t, ts.Add(4*time.Second), *summary.Timestamp())
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int("attempt", 2),
+		RepeatCountKey.Int64(3),
+		FirstTimestampKey.String("1970-01-01T00:01:41Z"),
+		LastTimestampKey.String("1970-01-01T00:01:44Z"),
+	}, *summary.Attributes())
+}
+
+func TestDedupProcessorForceFlush(t *testing.T) {
+	next := &testProcessor{}
+	processor, _ := newTestDedupProcessor(next)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	ts := time.Unix(100, 0)
+
+	emitMessage(logger, ts, "a")
+	emitMessage(logger, ts, "b")
+	emitMessage(logger, ts.Add(time.Second), "b")
+	emitMessage(logger, ts.Add(time.Second), "a")
+	require.NoError(t, processor.ForceFlush(context.Background()))
+	assert.Equal(t, []string{"a", "b", "a", "b"}, bodies(next.records))
+
+	// The windows ended, so the next log record is passed on.
+	emitMessage(logger, ts, "a")
+	emitMessage(logger, ts, "a")
+	require.NoError(t, processor.Shutdown(context.Background()))
+	require.Len(t, next.records, 6)
+	assert.Contains(t, *next.records[5].Attributes(), RepeatCountKey.Int64(1))
+}
+
+func TestDedupProcessorMaxFingerprints(t *testing.T) {
+	next := &testProcessor{}
+	processor, _ := newTestDedupProcessor(next, WithMaxDedupFingerprints(1))
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	ts := time.Unix(100, 0)
+
+	emitMessage(logger, ts, "a")
+	emitMessage(logger, ts, "b")
+	emitMessage(logger, ts, "b")
+	emitMessage(logger, ts, "a")
+	assert.Equal(t, []string{"a", "b", "b"}, bodies(next.records))
+}
+
+func TestDedupProcessorExpiry(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewDedupProcessor(next, time.Millisecond)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	emitMessage(logger, time.Now(), "a")
+	emitMessage(logger, time.Now(), "a")
+	assert.Eventually(t, func() bool {
+		next.mu.Lock()
+		defer next.mu.Unlock()
+		return len(next.records) == 2
+	}, time.Second, time.Millisecond)
+	require.NoError(t, processor.Shutdown(context.Background()))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"os"
+)
+
+// EnrichmentFunc returns the attributes to add to a log record emitted within
+// ctx.
+type EnrichmentFunc func(ctx context.Context, rol ReadableLogRecord) []attribute.KeyValue
+
+// EnrichmentProcessorOption configures an EnrichmentProcessor.
+type EnrichmentProcessorOption func(cfg *enrichmentConfig)
+
+type enrichmentConfig struct {
+	static      []attribute.KeyValue
+	baggageKeys []string
+	funcs       []EnrichmentFunc
+}
+
+// WithStaticAttributes adds attrs to every log record.
+func WithStaticAttributes(attrs ...attribute.KeyValue) EnrichmentProcessorOption {
+	return func(cfg *enrichmentConfig) {
+		cfg.static = append(cfg.static, attrs...)
+	}
+}
+
+// WithEnvironmentAttribute adds the value of the environment variable name,
+// read when the processor is created, to every log record as the attribute
+// key. Nothing is added if the variable is not set or empty.
+func WithEnvironmentAttribute(name string, key attribute.Key) EnrichmentProcessorOption {
+	return func(cfg *enrichmentConfig) {
+		if v := os.Getenv(name); v != "" {
+			cfg.static = append(cfg.static, key.String(v))
+		}
+	}
+}
+
+// WithBaggageAttributes adds the values of the baggage members with the given
+// keys in the context of a log record to it, as attributes with the same
+// keys. Other baggage members are not added.
+func WithBaggageAttributes(keys ...string) EnrichmentProcessorOption {
+	return func(cfg *enrichmentConfig) {
+		cfg.baggageKeys = append(cfg.baggageKeys, keys...)
+	}
+}
+
+// WithEnrichmentFunc adds the attributes returned by f to every log record.
+func WithEnrichmentFunc(f EnrichmentFunc) EnrichmentProcessorOption {
+	return func(cfg *enrichmentConfig) {
+		cfg.funcs = append(cfg.funcs, f)
+	}
+}
+
+// EnrichmentProcessor is a LogRecordProcessor that adds attributes to log
+// records before passing them on to another LogRecordProcessor.
+//
+// The attributes come from the static attributes and environment variables,
+// the baggage and the EnrichmentFuncs, in the order the options were given
+// within each of these sources. An attribute is only added if the log record
+// has no attribute or structured attribute with its key yet, so the values
+// set by the caller, and of the sources before, win.
+type EnrichmentProcessor struct {
+	next LogRecordProcessor
+	cfg  enrichmentConfig
+}
+
+var _ ContextLogRecordProcessor = (*EnrichmentProcessor)(nil)
+
+// NewEnrichmentProcessor returns an EnrichmentProcessor that adds the
+// attributes of the options to log records and passes them on to next.
+func NewEnrichmentProcessor(next LogRecordProcessor, opts ...EnrichmentProcessorOption) *EnrichmentProcessor {
+	var cfg enrichmentConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &EnrichmentProcessor{next: next, cfg: cfg}
+}
+
+// OnEmit adds the attributes to rol and passes it on.
+func (p *EnrichmentProcessor) OnEmit(rol ReadableLogRecord) {
+	p.OnEmitContext(context.Background(), rol)
+}
+
+// OnEmitContext adds the attributes to rol and passes it on within ctx.
+func (p *EnrichmentProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	rw, ok := rol.(ReadWriteLogRecord)
+	if !ok {
+		rw = copyLogRecord(rol)
+	}
+
+	keys := make(map[attribute.Key]struct{})
+	if attrs := rw.Attributes(); attrs != nil {
+		for _, a := range *attrs {
+			keys[a.Key] = struct{}{}
+		}
+	}
+	for _, kv := range rw.StructuredAttributes() {
+		keys[attribute.Key(kv.Key)] = struct{}{}
+	}
+
+	var added []attribute.KeyValue
+	add := func(attrs ...attribute.KeyValue) {
+		for _, a := range attrs {
+			if _, ok := keys[a.Key]; !ok {
+				keys[a.Key] = struct{}{}
+				added = append(added, a)
+			}
+		}
+	}
+	add(p.cfg.static...)
+	if len(p.cfg.baggageKeys) > 0 {
+		b := baggage.FromContext(ctx)
+		for _, key := range p.cfg.baggageKeys {
+			if m := b.Member(key); m.Key() != "" {
+				add(attribute.String(key, m.Value()))
+			}
+		}
+	}
+	for _, f := range p.cfg.funcs {
+		add(f(ctx, rw)...)
+	}
+
+	if len(added) > 0 {
+		rw.AddAttributes(added...)
+	}
+	onEmit(ctx, p.next, rw)
+}
+
+// Shutdown shuts the wrapped processor down.
+func (p *EnrichmentProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the wrapped processor.
+func (p *EnrichmentProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"testing"
+)
+
+func TestEnrichmentProcessor(t *testing.T) {
+	t.Setenv("POD_NAME", "pod-1")
+	t.Setenv("EMPTY", "")
+
+	tenant, err := baggage.NewMember("tenant.id", "t-42")
+	require.NoError(t, err)
+	secret, err := baggage.NewMember("session", "s")
+	require.NoError(t, err)
+	b, err := baggage.New(tenant, secret)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), b)
+
+	next := &testProcessor{}
+	processor := NewEnrichmentProcessor(next,
+		WithStaticAttributes(attribute.String("deployment.ring", "canary"), attribute.String("user", "static")),
+		WithEnvironmentAttribute("POD_NAME", "k8s.pod.name"),
+		WithEnvironmentAttribute("EMPTY", "empty"),
+		WithBaggageAttributes("tenant.id", "missing"),
+		WithEnrichmentFunc(func(ctx context.Context, rol ReadableLogRecord) []attribute.KeyValue {
+			return []attribute.KeyValue{
+				attribute.String("scope", rol.InstrumentationScope().Name),
+				attribute.String("deployment.ring", "func"),
+			}
+		}),
+	)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	attrs := []attribute.KeyValue{attribute.String("user", "ann")}
+
+	logger.EmitContext(ctx, logs.NewLogRecord(logs.LogRecordConfig{
+		Attributes:           &attrs,
+		StructuredAttributes: []logs.KeyValue{logs.String("k8s.pod.name", "caller")},
+	}))
+
+	require.Len(t, next.records, 1)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("user", "ann"),
+		attribute.String("deployment.ring", "canary"),
+		attribute.String("tenant.id", "t-42"),
+		attribute.String("scope", "test"),
+	}, *next.records[0].Attributes())
+	assert.Equal(t, []logs.KeyValue{logs.String("k8s.pod.name", "caller")}, next.records[0].StructuredAttributes())
+	assert.Len(t, attrs, 1)
+}
+
+func TestEnrichmentProcessorEnvironment(t *testing.T) {
+	t.Setenv("POD_NAME", "pod-1")
+
+	next := &testProcessor{}
+	processor := NewEnrichmentProcessor(next, WithEnvironmentAttribute("POD_NAME", "k8s.pod.name"))
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{}))
+
+	require.Len(t, next.records, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("k8s.pod.name", "pod-1")}, *next.records[0].Attributes())
+}
@@ -0,0 +1,234 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/agoda-com/opentelemetry-logs-go/sdk/internal/env"
+	"go.opentelemetry.io/otel/attribute"
+	"unicode/utf8"
+)
+
+// Defaults for LogRecordLimits.
+const (
+	// DefaultAttributeCountLimit is the default maximum number of attributes
+	// of a log record.
+	DefaultAttributeCountLimit = 128
+	// DefaultAttributeValueLengthLimit is the default maximum length of
+	// attribute values, no limit is applied.
+	DefaultAttributeValueLengthLimit = -1
+	// DefaultBodyLengthLimit is the default maximum length of the strings and
+	// bytes of log record bodies, no limit is applied.
+	DefaultBodyLengthLimit = -1
+)
+
+// LogRecordLimits represents the limits of a log record.
+// see https://opentelemetry.io/docs/specs/otel/logs/sdk/#logrecord-limits
+type LogRecordLimits struct {
+	// AttributeCountLimit is the maximum number of attributes of a log record,
+	// counting both attributes and structured attributes. Attributes added
+	// beyond it are dropped and counted in DroppedAttributesCount.
+	//
+	// Setting this to a negative value means no limit is applied.
+	AttributeCountLimit int
+
+	// AttributeValueLengthLimit is the maximum length of string and bytes
+	// attribute values, including the elements of slices and the values of
+	// maps. Strings are measured in characters. Longer values are truncated.
+	//
+	// Setting this to a negative value means no limit is applied.
+	AttributeValueLengthLimit int
+
+	// BodyLengthLimit is the AttributeValueLengthLimit of the log record
+	// body.
+	//
+	// Setting this to a negative value means no limit is applied.
+	BodyLengthLimit int
+}
+
+// NewLogRecordLimits returns a LogRecordLimits with the defaults, or the
+// values of the OTEL_LOGRECORD_ATTRIBUTE_COUNT_LIMIT and
+// OTEL_LOGRECORD_ATTRIBUTE_VALUE_LENGTH_LIMIT environment variables, falling
+// back to OTEL_ATTRIBUTE_COUNT_LIMIT and OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT.
+func NewLogRecordLimits() LogRecordLimits {
+	return LogRecordLimits{
+		AttributeCountLimit:       env.LogRecordAttributeCount(DefaultAttributeCountLimit),
+		AttributeValueLengthLimit: env.LogRecordAttributeValueLength(DefaultAttributeValueLengthLimit),
+		BodyLengthLimit:           DefaultBodyLengthLimit,
+	}
+}
+
+// WithLogRecordLimits configures the limits applied to the log records
+// emitted by the Loggers of the LoggerProvider.
+//
+// A zero field keeps its limit from NewLogRecordLimits, so only the limits
+// that are set change.
+func WithLogRecordLimits(limits LogRecordLimits) LoggerProviderOption {
+	return loggerProviderOptionFunc(func(cfg loggerProviderConfig) loggerProviderConfig {
+		if limits.AttributeCountLimit != 0 {
+			cfg.limits.AttributeCountLimit = limits.AttributeCountLimit
+		}
+		if limits.AttributeValueLengthLimit != 0 {
+			cfg.limits.AttributeValueLengthLimit = limits.AttributeValueLengthLimit
+		}
+		if limits.BodyLengthLimit != 0 {
+			cfg.limits.BodyLengthLimit = limits.BodyLengthLimit
+		}
+		return cfg
+	})
+}
+
+// exceedsString reports whether s is longer than limit characters.
+func exceedsString(limit int, s string) bool {
+	return limit >= 0 && len(s) > limit && utf8.RuneCountInString(s) > limit
+}
+
+// exceedsAttributeValue reports whether a string of v is longer than limit
+// characters.
+func exceedsAttributeValue(limit int, v attribute.Value) bool {
+	if limit < 0 {
+		return false
+	}
+	switch v.Type() {
+	case attribute.STRING:
+		return exceedsString(limit, v.AsString())
+	case attribute.STRINGSLICE:
+		// String slices can only be read as a copy.
+		for _, s := range v.AsStringSlice() {
+			if exceedsString(limit, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exceedsValue reports whether a string or bytes of v is longer than limit.
+func exceedsValue(limit int, v logs.Value) bool {
+	if limit < 0 {
+		return false
+	}
+	switch v.Kind() {
+	case logs.KindString:
+		return exceedsString(limit, v.AsString())
+	case logs.KindBytes:
+		return len(v.AsBytes()) > limit
+	case logs.KindSlice:
+		for _, elem := range v.AsSlice() {
+			if exceedsValue(limit, elem) {
+				return true
+			}
+		}
+	case logs.KindMap:
+		for _, kv := range v.AsMap() {
+			if exceedsValue(limit, kv.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// truncateString returns s truncated to limit characters and whether it was
+// truncated.
+func truncateString(limit int, s string) (string, bool) {
+	if limit < 0 || len(s) <= limit {
+		return s, false
+	}
+	n := 0
+	for i := range s {
+		if n == limit {
+			return s[:i], true
+		}
+		n++
+	}
+	return s, false
+}
+
+// truncateAttributeValue returns v with its strings truncated to limit and
+// whether anything was truncated.
+func truncateAttributeValue(limit int, v attribute.Value) (attribute.Value, bool) {
+	if limit < 0 {
+		return v, false
+	}
+	switch v.Type() {
+	case attribute.STRING:
+		if s, ok := truncateString(limit, v.AsString()); ok {
+			return attribute.StringValue(s), true
+		}
+	case attribute.STRINGSLICE:
+		ss := v.AsStringSlice()
+		truncated := false
+		for i, s := range ss {
+			if t, ok := truncateString(limit, s); ok {
+				ss[i] = t
+				truncated = true
+			}
+		}
+		if truncated {
+			return attribute.StringSliceValue(ss), true
+		}
+	}
+	return v, false
+}
+
+// truncateValue returns v with its strings and bytes truncated to limit and
+// whether anything was truncated.
+func truncateValue(limit int, v logs.Value) (logs.Value, bool) {
+	if limit < 0 {
+		return v, false
+	}
+	switch v.Kind() {
+	case logs.KindString:
+		if s, ok := truncateString(limit, v.AsString()); ok {
+			return logs.StringValue(s), true
+		}
+	case logs.KindBytes:
+		if b := v.AsBytes(); len(b) > limit {
+			return logs.BytesValue(b[:limit:limit]), true
+		}
+	case logs.KindSlice:
+		var elems []logs.Value
+		for i, elem := range v.AsSlice() {
+			if t, ok := truncateValue(limit, elem); ok {
+				if elems == nil {
+					elems = make([]logs.Value, len(v.AsSlice()))
+					copy(elems, v.AsSlice())
+				}
+				elems[i] = t
+			}
+		}
+		if elems != nil {
+			return logs.SliceValue(elems...), true
+		}
+	case logs.KindMap:
+		var kvs []logs.KeyValue
+		for i, kv := range v.AsMap() {
+			if t, ok := truncateValue(limit, kv.Value); ok {
+				if kvs == nil {
+					kvs = make([]logs.KeyValue, len(v.AsMap()))
+					copy(kvs, v.AsMap())
+				}
+				kvs[i].Value = t
+			}
+		}
+		if kvs != nil {
+			return logs.MapValue(kvs...), true
+		}
+	}
+	return v, false
+}
@@ -0,0 +1,182 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"testing"
+)
+
+func TestTruncateString(t *testing.T) {
+	for _, test := range []struct {
+		limit     int
+		in, want  string
+		truncated bool
+	}{
+		{-1, "hello", "hello", false},
+		{5, "hello", "hello", false},
+		{3, "hello", "hel", true},
+		{0, "hello", "", true},
+		{2, "héllo", "hé", true},
+		{5, "héllo", "héllo", false},
+		{1, "日本", "日", true},
+	} {
+		got, truncated := truncateString(test.limit, test.in)
+		assert.Equal(t, test.want, got, test.in)
+		assert.Equal(t, test.truncated, truncated, test.in)
+	}
+}
+
+func TestNewLogRecordLimits(t *testing.T) {
+	assert.Equal(t, LogRecordLimits{
+		AttributeCountLimit:       DefaultAttributeCountLimit,
+		AttributeValueLengthLimit: DefaultAttributeValueLengthLimit,
+		BodyLengthLimit:           DefaultBodyLengthLimit,
+	}, NewLogRecordLimits())
+
+	t.Setenv("OTEL_ATTRIBUTE_COUNT_LIMIT", "10")
+	t.Setenv("OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT", "20")
+	t.Setenv("OTEL_LOGRECORD_ATTRIBUTE_COUNT_LIMIT", "3")
+	assert.Equal(t, LogRecordLimits{
+		AttributeCountLimit:       3,
+		AttributeValueLengthLimit: 20,
+		BodyLengthLimit:           DefaultBodyLengthLimit,
+	}, NewLogRecordLimits())
+}
+
+func TestLoggerLogRecordLimits(t *testing.T) {
+	processor := &testProcessor{}
+	limits := NewLogRecordLimits()
+	limits.AttributeCountLimit = 3
+	limits.AttributeValueLengthLimit = 4
+	limits.BodyLengthLimit = 5
+	logger := NewLoggerProvider(
+		WithLogRecordLimits(limits),
+		WithLogRecordProcessor(funcProcessor{onEmit: func(rol ReadableLogRecord) {
+			rol.(ReadWriteLogRecord).AddAttributes(attribute.String("added", "value"))
+		}}),
+		WithLogRecordProcessor(processor),
+	).Logger("test")
+
+	attrs := []attribute.KeyValue{
+		attribute.String("a", "truncated"),
+		attribute.StringSlice("b", []string{"ok", "truncated"}),
+		attribute.Int("c", 123456),
+	}
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		BodyValue:  logs.MapValue(logs.String("msg", "too long"), logs.Bytes("raw", []byte("123456"))),
+		Attributes: &attrs,
+		StructuredAttributes: []logs.KeyValue{
+			logs.String("d", "dropped"),
+		},
+	}))
+
+	require.Len(t, processor.records, 1)
+	record := processor.records[0]
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("a", "trun"),
+		attribute.StringSlice("b", []string{"ok", "trun"}),
+		attribute.Int("c", 123456),
+	}, *record.Attributes())
+	assert.Empty(t, record.StructuredAttributes())
+	assert.Equal(t, 2, record.DroppedAttributesCount())
+	assert.Equal(t, logs.MapValue(logs.String("msg", "too l"), logs.Bytes("raw", []byte("12345"))), record.BodyValue())
+
+	// The attributes of the caller are not modified.
+	assert.Equal(t, "truncated", attrs[0].Value.AsString())
+}
+
+func TestLoggerLogRecordLimitsUnlimited(t *testing.T) {
+	processor := &testProcessor{}
+	logger := NewLoggerProvider(
+		WithLogRecordLimits(LogRecordLimits{AttributeCountLimit: -1, AttributeValueLengthLimit: -1, BodyLengthLimit: -1}),
+		WithLogRecordProcessor(processor),
+	).Logger("test")
+
+	attrs := make([]attribute.KeyValue, 200)
+	for i := range attrs {
+		attrs[i] = attribute.Int("key", i)
+	}
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{Attributes: &attrs}))
+
+	require.Len(t, processor.records, 1)
+	assert.Len(t, *processor.records[0].Attributes(), 200)
+	assert.Zero(t, processor.records[0].DroppedAttributesCount())
+}
+
+func TestLoggerLogRecordLimitsDefaults(t *testing.T) {
+	processor := &testProcessor{}
+	logger := NewLoggerProvider(
+		WithLogRecordLimits(LogRecordLimits{AttributeCountLimit: 2}),
+		WithLogRecordProcessor(processor),
+	).Logger("test")
+
+	attrs := []attribute.KeyValue{
+		attribute.String("a", "value"),
+		attribute.String("b", "value"),
+		attribute.String("c", "value"),
+	}
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		BodyValue:  logs.StringValue("body"),
+		Attributes: &attrs,
+	}))
+
+	require.Len(t, processor.records, 1)
+	record := processor.records[0]
+	assert.Equal(t, attrs[:2], *record.Attributes())
+	assert.Equal(t, 1, record.DroppedAttributesCount())
+	assert.Equal(t, logs.StringValue("body"), record.BodyValue())
+}
+
+func TestLoggerLogRecordLimitsEnvironment(t *testing.T) {
+	t.Setenv("OTEL_LOGRECORD_ATTRIBUTE_VALUE_LENGTH_LIMIT", "2")
+	processor := &testProcessor{}
+	logger := NewLoggerProvider(
+		WithLogRecordLimits(LogRecordLimits{BodyLengthLimit: 3}),
+		WithLogRecordProcessor(processor),
+	).Logger("test")
+
+	attrs := []attribute.KeyValue{attribute.String("a", "value")}
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		BodyValue:  logs.StringValue("body"),
+		Attributes: &attrs,
+	}))
+
+	require.Len(t, processor.records, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("a", "va")}, *processor.records[0].Attributes())
+	assert.Equal(t, logs.StringValue("bod"), processor.records[0].BodyValue())
+}
+
+func TestExceedsLimit(t *testing.T) {
+	assert.False(t, exceedsString(-1, "hello"))
+	assert.False(t, exceedsString(5, "héllo"))
+	assert.True(t, exceedsString(4, "héllo"))
+	assert.True(t, exceedsAttributeValue(1, attribute.StringSliceValue([]string{"a", "bc"})))
+	assert.False(t, exceedsAttributeValue(1, attribute.Int64Value(100)))
+	assert.True(t, exceedsValue(1, logs.MapValue(logs.Slice("s", logs.BytesValue([]byte{1, 2})))))
+	assert.False(t, exceedsValue(2, logs.MapValue(logs.String("s", "ab"))))
+
+	record := &exportableLogRecord{
+		limits:               &LogRecordLimits{AttributeCountLimit: 10, AttributeValueLengthLimit: 10},
+		attributes:           &[]attribute.KeyValue{attribute.String("a", "value")},
+		structuredAttributes: []logs.KeyValue{logs.Map("m", logs.String("k", "v"))},
+	}
+	assert.Zero(t, testing.AllocsPerRun(10, func() { record.withinLimits() }))
+}
@@ -18,6 +18,7 @@ package logs
 
 import (
 	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	"sync"
 )
 
@@ -30,6 +31,12 @@ type LogRecordProcessor interface {
 
 	// OnEmit is called when logs sent. It is called synchronously and
 	// hence not block.
+	//
+	// Processors are called in the order they were registered, all with the
+	// same log record. Log records emitted through a Logger of this SDK also
+	// implement ReadWriteLogRecord, changes made to them are seen by the
+	// processors registered after. A processor that keeps the log record
+	// after OnEmit returns must not modify it any more.
 	OnEmit(rol ReadableLogRecord)
 	// DO NOT CHANGE: any modification will not be backwards compatible and
 	// must never be done outside of a new major release.
@@ -55,6 +62,51 @@ type LogRecordProcessor interface {
 	// must never be done outside of a new major release.
 }
 
+// ContextLogRecordProcessor is an optional interface a LogRecordProcessor can
+// implement to receive the context passed to logs.Logger.EmitContext.
+//
+// When a processor implements this interface OnEmitContext is called instead
+// of OnEmit. Log records emitted with logs.Logger.Emit are passed with
+// context.Background().
+type ContextLogRecordProcessor interface {
+	LogRecordProcessor
+
+	// OnEmitContext is called when logs sent. It is called synchronously and
+	// hence not block.
+	OnEmitContext(ctx context.Context, rol ReadableLogRecord)
+}
+
+// FilterProcessor is an optional interface a LogRecordProcessor can implement
+// to tell the SDK ahead of time whether it would process a log record.
+//
+// The answer is used by logs.Logger.Enabled. A processor that does not
+// implement this interface is assumed to process every log record.
+type FilterProcessor interface {
+	// Enabled reports whether the processor would process a log record with
+	// the given parameters emitted within ctx.
+	//
+	// It is called synchronously on the caller's hot path, it should be
+	// cheap and must not block.
+	Enabled(ctx context.Context, param logs.EnabledParameters) bool
+}
+
+// enabled reports whether lp would process a log record with param.
+func enabled(ctx context.Context, lp LogRecordProcessor, param logs.EnabledParameters) bool {
+	if fp, ok := lp.(FilterProcessor); ok {
+		return fp.Enabled(ctx, param)
+	}
+	return true
+}
+
+// onEmit passes rol to lp, using OnEmitContext when lp supports it.
+func onEmit(ctx context.Context, lp LogRecordProcessor, rol ReadableLogRecord) {
+	if clp, ok := lp.(ContextLogRecordProcessor); ok {
+		clp.OnEmitContext(ctx, rol)
+		return
+	}
+	lp.OnEmit(rol)
+}
+
 type logRecordProcessorState struct {
 	lp    LogRecordProcessor
 	state sync.Once
@@ -17,6 +17,7 @@ limitations under the License.
 package logs
 
 import (
+	"context"
 	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	"github.com/agoda-com/opentelemetry-logs-go/semconv"
 	"go.opentelemetry.io/otel/attribute"
@@ -24,27 +25,47 @@ import (
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/trace"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type logger struct {
 	provider             *LoggerProvider
 	instrumentationScope instrumentation.Scope
+	configState          atomic.Pointer[loggerConfigState]
 }
 
 var _ logs.Logger = &logger{}
 
-func (l logger) Emit(logRecord logs.LogRecord) {
+func (l *logger) Emit(logRecord logs.LogRecord) {
+	l.EmitContext(context.Background(), logRecord)
+}
+
+func (l *logger) EmitContext(ctx context.Context, logRecord logs.LogRecord) {
 	lps := l.provider.getLogRecordProcessorStates()
 	if len(lps) == 0 {
 		return
 	}
 
+	sn := logs.UNSPECIFIED
+	if logRecord.SeverityNumber() != nil {
+		sn = *logRecord.SeverityNumber()
+	}
+	if !l.config().enabledFor(sn) {
+		return
+	}
+
 	pr, err := resource.Merge(l.provider.resource, logRecord.Resource())
 	if err != nil {
 		return
 	}
 
+	// The Logger's scope is used unless the bridge set one on the record.
+	is := logRecord.InstrumentationScope()
+	if is == nil {
+		is = &l.instrumentationScope
+	}
+
 	elr := &exportableLogRecord{
 		timestamp:            logRecord.Timestamp(),
 		observedTimestamp:    logRecord.ObservedTimestamp(),
@@ -53,17 +74,36 @@ func (l logger) Emit(logRecord logs.LogRecord) {
 		traceFlags:           logRecord.TraceFlags(),
 		severityText:         logRecord.SeverityText(),
 		severityNumber:       logRecord.SeverityNumber(),
-		body:                 logRecord.Body(),
+		body:                 logRecord.BodyValue(),
+		bodyAny:              logRecord.Body(),
 		resource:             pr,
-		instrumentationScope: logRecord.InstrumentationScope(),
+		instrumentationScope: is,
 		attributes:           logRecord.Attributes(),
+		structuredAttributes: logRecord.StructuredAttributes(),
+		eventName:            logRecord.EventName(),
 	}
+	elr.applyLimits(&l.provider.limits)
+	elr.setSpanContext(trace.SpanContextFromContext(ctx))
 
 	for _, lp := range lps {
-		lp.lp.OnEmit(elr)
+		onEmit(ctx, lp.lp, elr)
 	}
 }
 
+// Enabled reports whether any of the registered processors would process a
+// log record with param.
+func (l *logger) Enabled(ctx context.Context, param logs.EnabledParameters) bool {
+	if !l.config().enabledFor(param.Severity) {
+		return false
+	}
+	for _, lp := range l.provider.getLogRecordProcessorStates() {
+		if enabled(ctx, lp.lp, param) {
+			return true
+		}
+	}
+	return false
+}
+
 // ReadableLogRecord Log structure
 // see https://opentelemetry.io/docs/specs/otel/logs/data-model/#log-and-event-record-definition
 // see https://opentelemetry.io/docs/specs/otel/logs/sdk/#readablelogrecord
@@ -82,7 +122,12 @@ type ReadableLogRecord interface {
 	SeverityText() *string
 	// SeverityNumber	Numerical value of the severityNumber.
 	SeverityNumber() *logs.SeverityNumber
-	// Body The body of the log record.
+	// BodyValue The body of the log record.
+	BodyValue() logs.Value
+	// Body The body of the log record as it was set with the deprecated
+	// fields and methods, or as a Go value of BodyValue, see logs.Value.Any.
+	//
+	// Deprecated: use BodyValue instead.
 	Body() any
 	// Resource 	Describes the source of the log.
 	Resource() *resource.Resource
@@ -91,6 +136,14 @@ type ReadableLogRecord interface {
 	InstrumentationScope() *instrumentation.Scope
 	// Attributes describe the aspects of the event.
 	Attributes() *[]attribute.KeyValue
+	// StructuredAttributes describe the aspects of the event with values
+	// that can also be maps, slices of mixed kinds or bytes.
+	StructuredAttributes() []logs.KeyValue
+	// EventName The name of the event the log record represents, if any.
+	EventName() string
+	// DroppedAttributesCount The number of attributes dropped because of the
+	// LogRecordLimits.
+	DroppedAttributesCount() int
 
 	// A private method to prevent users implementing the
 	// interface and so future additions to it will not
@@ -98,8 +151,51 @@ type ReadableLogRecord interface {
 	private()
 }
 
+// ReadWriteLogRecord is a log record that processors can modify before it is
+// passed on to the next processor.
+// see https://opentelemetry.io/docs/specs/otel/logs/sdk/#readwritelogrecord
 type ReadWriteLogRecord interface {
+	// SetTimestamp sets the time when the event occurred.
+	SetTimestamp(timestamp time.Time)
+	// SetObservedTimestamp sets the time when the event was observed.
+	SetObservedTimestamp(timestamp time.Time)
+	// SetSeverityText sets the original string representation of the severity.
+	SetSeverityText(text string)
+	// SetSeverityNumber sets the numerical value of the severity.
+	SetSeverityNumber(severity logs.SeverityNumber)
+	// SetEventName sets the event name of the log record.
+	SetEventName(name string)
+	// SetBodyValue sets the body of the log record.
+	SetBodyValue(body logs.Value)
+	// SetBody sets the body of the log record to the Value of body, see
+	// logs.ValueOf.
+	//
+	// Deprecated: use SetBodyValue instead.
+	SetBody(body any)
 	SetResource(resource *resource.Resource)
+	// AddAttributes appends attrs to the attributes of the log record. The
+	// LogRecordLimits of the LoggerProvider apply to them.
+	AddAttributes(attrs ...attribute.KeyValue)
+	// SetAttribute sets kv, replacing any attribute or structured attribute
+	// with the same key.
+	SetAttribute(kv attribute.KeyValue)
+	// AddStructuredAttributes appends attrs to the structured attributes of
+	// the log record. The LogRecordLimits of the LoggerProvider apply to them.
+	AddStructuredAttributes(attrs ...logs.KeyValue)
+	// SetStructuredAttribute sets kv, replacing any attribute or structured
+	// attribute with the same key.
+	SetStructuredAttribute(kv logs.KeyValue)
+	// RemoveAttribute removes all attributes and structured attributes with
+	// the given key.
+	RemoveAttribute(key attribute.Key)
+	// SetAttributes replaces the attributes of the log record with attrs,
+	// keeping the structured attributes. The LogRecordLimits of the
+	// LoggerProvider apply to them.
+	SetAttributes(attrs ...attribute.KeyValue)
+	// SetStructuredAttributes replaces the structured attributes of the log
+	// record with attrs, keeping the attributes. The LogRecordLimits of the
+	// LoggerProvider apply to them.
+	SetStructuredAttributes(attrs ...logs.KeyValue)
 	// RecordException message, stacktrace, type
 	RecordException(*string, *string, *string)
 	ReadableLogRecord
@@ -117,10 +213,21 @@ type exportableLogRecord struct {
 	traceFlags           *trace.TraceFlags
 	severityText         *string
 	severityNumber       *logs.SeverityNumber
-	body                 any
+	body                 logs.Value
+	bodyAny              any
 	resource             *resource.Resource
 	instrumentationScope *instrumentation.Scope
 	attributes           *[]attribute.KeyValue
+	structuredAttributes []logs.KeyValue
+	eventName            string
+	// limits are applied to the attributes added to the record, if not nil.
+	limits            *LogRecordLimits
+	droppedAttributes int
+	// attributesOwned and structuredAttributesOwned are set once the
+	// attributes were copied from the slices provided by the caller, so they
+	// can be modified in place.
+	attributesOwned           bool
+	structuredAttributesOwned bool
 }
 
 // newReadWriteLogRecord create
@@ -139,6 +246,13 @@ func newReadWriteLogRecord(
 	spanId := ctx.SpanID()
 	traceFlags := ctx.TraceFlags()
 
+	var bodyValue logs.Value
+	var bodyAny any
+	if body != nil {
+		bodyValue = logs.StringValue(*body)
+		bodyAny = body
+	}
+
 	return &exportableLogRecord{
 		timestamp:            timestamp,
 		observedTimestamp:    time.Now(),
@@ -147,15 +261,263 @@ func newReadWriteLogRecord(
 		traceFlags:           &traceFlags,
 		severityText:         severityText,
 		severityNumber:       severityNumber,
-		body:                 body,
+		body:                 bodyValue,
+		bodyAny:              bodyAny,
 		resource:             resource,
 		instrumentationScope: instrumentationScope,
 		attributes:           attributes,
 	}
 }
 
+// setSpanContext fills the trace correlation fields that are not already set
+// on the record from sc. Invalid span contexts are ignored.
+func (r *exportableLogRecord) setSpanContext(sc trace.SpanContext) {
+	if !sc.IsValid() {
+		return
+	}
+	if r.traceId == nil {
+		traceId := sc.TraceID()
+		r.traceId = &traceId
+	}
+	if r.spanId == nil {
+		spanId := sc.SpanID()
+		r.spanId = &spanId
+	}
+	if r.traceFlags == nil {
+		traceFlags := sc.TraceFlags()
+		r.traceFlags = &traceFlags
+	}
+}
+
+func (r *exportableLogRecord) SetTimestamp(timestamp time.Time) {
+	r.timestamp = &timestamp
+}
+
+func (r *exportableLogRecord) SetObservedTimestamp(timestamp time.Time) {
+	r.observedTimestamp = timestamp
+}
+
+func (r *exportableLogRecord) SetSeverityText(text string) {
+	r.severityText = &text
+}
+
+func (r *exportableLogRecord) SetSeverityNumber(severity logs.SeverityNumber) {
+	r.severityNumber = &severity
+}
+
+func (r *exportableLogRecord) SetEventName(name string) {
+	r.eventName = name
+}
+
+func (r *exportableLogRecord) SetBodyValue(body logs.Value) {
+	r.body = body
+	r.bodyAny = nil
+}
+
+func (r *exportableLogRecord) SetBody(body any) {
+	r.body = logs.ValueOf(body)
+	r.bodyAny = body
+}
+
 func (r *exportableLogRecord) SetResource(resource *resource.Resource) { r.resource = resource }
 
+// ownAttributes makes sure the attributes of the record can be modified
+// without affecting the slice passed by the caller or shared with a clone.
+func (r *exportableLogRecord) ownAttributes(extra int) {
+	if r.attributesOwned {
+		return
+	}
+	var attrs []attribute.KeyValue
+	if r.attributes != nil {
+		attrs = make([]attribute.KeyValue, len(*r.attributes), len(*r.attributes)+extra)
+		copy(attrs, *r.attributes)
+	} else {
+		attrs = make([]attribute.KeyValue, 0, extra)
+	}
+	r.attributes = &attrs
+	r.attributesOwned = true
+}
+
+// applyLimits applies limits to the record and to the attributes added to it
+// later on.
+func (r *exportableLogRecord) applyLimits(limits *LogRecordLimits) {
+	r.limits = limits
+	var truncated bool
+	if r.body, truncated = truncateValue(limits.BodyLengthLimit, r.body); truncated {
+		// The original body is not truncated.
+		r.bodyAny = nil
+	}
+
+	if r.withinLimits() {
+		return
+	}
+	var attrs []attribute.KeyValue
+	if r.attributes != nil {
+		attrs = *r.attributes
+	}
+	structured := r.structuredAttributes
+	r.attributes, r.attributesOwned = nil, false
+	r.structuredAttributes, r.structuredAttributesOwned = nil, false
+	r.AddAttributes(attrs...)
+	r.AddStructuredAttributes(structured...)
+}
+
+// withinLimits reports whether the attributes of the record are within its
+// limits.
+func (r *exportableLogRecord) withinLimits() bool {
+	if r.limits == nil {
+		return true
+	}
+	var attrs []attribute.KeyValue
+	if r.attributes != nil {
+		attrs = *r.attributes
+	}
+	if limit := r.limits.AttributeCountLimit; limit >= 0 && len(attrs)+len(r.structuredAttributes) > limit {
+		return false
+	}
+	if limit := r.limits.AttributeValueLengthLimit; limit >= 0 {
+		for _, a := range attrs {
+			if exceedsAttributeValue(limit, a.Value) {
+				return false
+			}
+		}
+		for _, kv := range r.structuredAttributes {
+			if exceedsValue(limit, kv.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// attributeRoom returns how many of n attributes can be added within the
+// attribute count limit and counts the others as dropped.
+func (r *exportableLogRecord) attributeRoom(n int) int {
+	if r.limits == nil || r.limits.AttributeCountLimit < 0 {
+		return n
+	}
+	count := len(r.structuredAttributes)
+	if r.attributes != nil {
+		count += len(*r.attributes)
+	}
+	room := r.limits.AttributeCountLimit - count
+	if room < 0 {
+		room = 0
+	}
+	if n > room {
+		r.droppedAttributes += n - room
+		return room
+	}
+	return n
+}
+
+func (r *exportableLogRecord) AddAttributes(attrs ...attribute.KeyValue) {
+	attrs = attrs[:r.attributeRoom(len(attrs))]
+	if len(attrs) == 0 {
+		return
+	}
+	r.ownAttributes(len(attrs))
+	for _, a := range attrs {
+		if r.limits != nil {
+			a.Value, _ = truncateAttributeValue(r.limits.AttributeValueLengthLimit, a.Value)
+		}
+		*r.attributes = append(*r.attributes, a)
+	}
+}
+
+func (r *exportableLogRecord) SetAttribute(kv attribute.KeyValue) {
+	r.RemoveAttribute(kv.Key)
+	r.AddAttributes(kv)
+}
+
+func (r *exportableLogRecord) SetAttributes(attrs ...attribute.KeyValue) {
+	r.attributes = nil
+	r.attributesOwned = false
+	r.AddAttributes(attrs...)
+}
+
+func (r *exportableLogRecord) SetStructuredAttributes(attrs ...logs.KeyValue) {
+	r.structuredAttributes = nil
+	r.structuredAttributesOwned = false
+	r.AddStructuredAttributes(attrs...)
+}
+
+func (r *exportableLogRecord) RemoveAttribute(key attribute.Key) {
+	r.removeStructuredAttribute(string(key))
+	if r.attributes == nil {
+		return
+	}
+	found := false
+	for _, a := range *r.attributes {
+		if a.Key == key {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	r.ownAttributes(0)
+	attrs := (*r.attributes)[:0]
+	for _, a := range *r.attributes {
+		if a.Key != key {
+			attrs = append(attrs, a)
+		}
+	}
+	*r.attributes = attrs
+}
+
+// ownStructuredAttributes is ownAttributes for the structured attributes.
+func (r *exportableLogRecord) ownStructuredAttributes(extra int) {
+	if r.structuredAttributesOwned {
+		return
+	}
+	attrs := make([]logs.KeyValue, len(r.structuredAttributes), len(r.structuredAttributes)+extra)
+	copy(attrs, r.structuredAttributes)
+	r.structuredAttributes = attrs
+	r.structuredAttributesOwned = true
+}
+
+func (r *exportableLogRecord) AddStructuredAttributes(attrs ...logs.KeyValue) {
+	attrs = attrs[:r.attributeRoom(len(attrs))]
+	if len(attrs) == 0 {
+		return
+	}
+	r.ownStructuredAttributes(len(attrs))
+	for _, kv := range attrs {
+		if r.limits != nil {
+			kv.Value, _ = truncateValue(r.limits.AttributeValueLengthLimit, kv.Value)
+		}
+		r.structuredAttributes = append(r.structuredAttributes, kv)
+	}
+}
+
+func (r *exportableLogRecord) SetStructuredAttribute(kv logs.KeyValue) {
+	r.RemoveAttribute(attribute.Key(kv.Key))
+	r.AddStructuredAttributes(kv)
+}
+
+func (r *exportableLogRecord) removeStructuredAttribute(key string) {
+	found := false
+	for _, kv := range r.structuredAttributes {
+		if kv.Key == key {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	r.ownStructuredAttributes(0)
+	attrs := r.structuredAttributes[:0]
+	for _, kv := range r.structuredAttributes {
+		if kv.Key != key {
+			attrs = append(attrs, kv)
+		}
+	}
+	r.structuredAttributes = attrs
+}
+
 // RecordException helper to add Exception related information as attributes of Log Record
 // see https://opentelemetry.io/docs/specs/otel/logs/semantic_conventions/exceptions/#recording-an-exception
 func (r *exportableLogRecord) RecordException(message *string, stacktrace *string, exceptionType *string) {
@@ -164,13 +526,64 @@ func (r *exportableLogRecord) RecordException(message *string, stacktrace *strin
 		return
 	}
 	if message != nil {
-		*r.attributes = append(*r.attributes, semconv.ExceptionMessage(*message))
+		r.AddAttributes(semconv.ExceptionMessage(*message))
 	}
 	if stacktrace != nil {
-		*r.attributes = append(*r.attributes, semconv.ExceptionStacktrace(*stacktrace))
+		r.AddAttributes(semconv.ExceptionStacktrace(*stacktrace))
 	}
 	if exceptionType != nil {
-		*r.attributes = append(*r.attributes, semconv.ExceptionType(*exceptionType))
+		r.AddAttributes(semconv.ExceptionType(*exceptionType))
+	}
+}
+
+// clone returns a copy of the record that is not affected by later
+// modifications of r.
+func (r *exportableLogRecord) clone() *exportableLogRecord {
+	// Both records share the attributes until either one is modified.
+	r.attributesOwned = false
+	r.structuredAttributesOwned = false
+	return &exportableLogRecord{
+		timestamp:            r.timestamp,
+		observedTimestamp:    r.observedTimestamp,
+		traceId:              r.traceId,
+		spanId:               r.spanId,
+		traceFlags:           r.traceFlags,
+		severityText:         r.severityText,
+		severityNumber:       r.severityNumber,
+		body:                 r.body,
+		bodyAny:              r.bodyAny,
+		resource:             r.resource,
+		instrumentationScope: r.instrumentationScope,
+		attributes:           r.attributes,
+		structuredAttributes: r.structuredAttributes,
+		eventName:            r.eventName,
+		limits:               r.limits,
+		droppedAttributes:    r.droppedAttributes,
+	}
+}
+
+// copyLogRecord returns a copy of rol that can be modified without affecting
+// rol.
+func copyLogRecord(rol ReadableLogRecord) *exportableLogRecord {
+	if elr, ok := rol.(*exportableLogRecord); ok {
+		return elr.clone()
+	}
+	return &exportableLogRecord{
+		timestamp:            rol.Timestamp(),
+		observedTimestamp:    rol.ObservedTimestamp(),
+		traceId:              rol.TraceId(),
+		spanId:               rol.SpanId(),
+		traceFlags:           rol.TraceFlags(),
+		severityText:         rol.SeverityText(),
+		severityNumber:       rol.SeverityNumber(),
+		body:                 rol.BodyValue(),
+		bodyAny:              rol.Body(),
+		resource:             rol.Resource(),
+		instrumentationScope: rol.InstrumentationScope(),
+		attributes:           rol.Attributes(),
+		structuredAttributes: rol.StructuredAttributes(),
+		eventName:            rol.EventName(),
+		droppedAttributes:    rol.DroppedAttributesCount(),
 	}
 }
 
@@ -184,7 +597,20 @@ func (r *exportableLogRecord) InstrumentationScope() *instrumentation.Scope {
 }
 func (r *exportableLogRecord) SeverityText() *string                { return r.severityText }
 func (r *exportableLogRecord) SeverityNumber() *logs.SeverityNumber { return r.severityNumber }
-func (r *exportableLogRecord) Body() any                            { return r.body }
-func (r *exportableLogRecord) Resource() *resource.Resource         { return r.resource }
-func (r *exportableLogRecord) Attributes() *[]attribute.KeyValue    { return r.attributes }
-func (r *exportableLogRecord) private()                             {}
+func (r *exportableLogRecord) BodyValue() logs.Value                { return r.body }
+func (r *exportableLogRecord) Body() any {
+	if r.bodyAny != nil {
+		return r.bodyAny
+	}
+	return r.body.Any()
+}
+func (r *exportableLogRecord) Resource() *resource.Resource      { return r.resource }
+func (r *exportableLogRecord) Attributes() *[]attribute.KeyValue { return r.attributes }
+func (r *exportableLogRecord) StructuredAttributes() []logs.KeyValue {
+	return r.structuredAttributes
+}
+func (r *exportableLogRecord) EventName() string { return r.eventName }
+func (r *exportableLogRecord) DroppedAttributesCount() int {
+	return r.droppedAttributes
+}
+func (r *exportableLogRecord) private() {}
@@ -0,0 +1,86 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+// LoggerConfig is the configuration of the Loggers of an instrumentation
+// scope. The zero value enables the Loggers for all log records.
+// see https://opentelemetry.io/docs/specs/otel/logs/sdk/#loggerconfig
+type LoggerConfig struct {
+	// Disabled makes the Loggers drop all log records and report that they
+	// are not enabled.
+	Disabled bool
+	// MinSeverity makes the Loggers drop the log records with a lower
+	// severity. Log records without a severity are not dropped.
+	MinSeverity logs.SeverityNumber
+}
+
+// LoggerConfigurator returns the LoggerConfig of the Loggers of an
+// instrumentation scope.
+// see https://opentelemetry.io/docs/specs/otel/logs/sdk/#loggerconfigurator
+type LoggerConfigurator func(instrumentation.Scope) LoggerConfig
+
+// WithLoggerConfigurator will configure the Loggers of each instrumentation
+// scope with configurator. It is called once for each Logger, when it is
+// created and again after LoggerProvider.SetLoggerConfigurator, so it should
+// return the same LoggerConfig for the same scope.
+func WithLoggerConfigurator(configurator LoggerConfigurator) LoggerProviderOption {
+	return loggerProviderOptionFunc(func(cfg loggerProviderConfig) loggerProviderConfig {
+		cfg.configurator = configurator
+		return cfg
+	})
+}
+
+// loggerConfigurator holds a LoggerConfigurator, its address identifies the
+// LoggerConfigurator set last.
+type loggerConfigurator struct {
+	f LoggerConfigurator
+}
+
+// loggerConfigState is the LoggerConfig of a logger and the configurator it
+// was returned by.
+type loggerConfigState struct {
+	configurator *loggerConfigurator
+	config       LoggerConfig
+}
+
+// config returns the LoggerConfig of l, asking the configurator of the
+// provider again if it was replaced.
+func (l *logger) config() LoggerConfig {
+	c := l.provider.configurator.Load()
+	if s := l.configState.Load(); s != nil && s.configurator == c {
+		return s.config
+	}
+	var config LoggerConfig
+	if c.f != nil {
+		config = c.f(l.instrumentationScope)
+	}
+	l.configState.Store(&loggerConfigState{configurator: c, config: config})
+	return config
+}
+
+// enabledFor reports whether l passes on log records with severity sn.
+func (c LoggerConfig) enabledFor(sn logs.SeverityNumber) bool {
+	if c.Disabled {
+		return false
+	}
+	return sn == logs.UNSPECIFIED || sn >= c.MinSeverity
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package logs
 
 import (
+	"context"
 	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
@@ -69,3 +71,270 @@ func TestLogsReadWriteAPIFormat(t *testing.T) {
 	assert.Equal(t, "My Log Message", *(record.Body().(*string)))
 
 }
+
+type ctxKey struct{}
+
+func TestLoggerEmitContext(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("80f198ee56343ba864fe8b2a57d3eff7")
+	spanID, _ := trace.SpanIDFromHex("2a00000000000000")
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.WithValue(context.Background(), ctxKey{}, "value"), spanCtx)
+
+	processor := &testProcessor{}
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	body := "body"
+	logger.EmitContext(ctx, logs.NewLogRecord(logs.LogRecordConfig{Body: &body}))
+
+	otherSpanID, _ := trace.SpanIDFromHex("2b00000000000000")
+	logger.EmitContext(ctx, logs.NewLogRecord(logs.LogRecordConfig{Body: &body, SpanId: &otherSpanID}))
+
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{Body: &body}))
+
+	assert.Len(t, processor.records, 3)
+
+	assert.Equal(t, traceID, *processor.records[0].TraceId())
+	assert.Equal(t, spanID, *processor.records[0].SpanId())
+	assert.Equal(t, trace.FlagsSampled, *processor.records[0].TraceFlags())
+	assert.Equal(t, "value", processor.ctxs[0].Value(ctxKey{}))
+
+	assert.Equal(t, traceID, *processor.records[1].TraceId())
+	assert.Equal(t, otherSpanID, *processor.records[1].SpanId())
+
+	assert.Nil(t, processor.records[2].TraceId())
+	assert.Nil(t, processor.records[2].SpanId())
+	assert.Nil(t, processor.records[2].TraceFlags())
+}
+
+func TestLoggerEventName(t *testing.T) {
+	rename := funcProcessor{onEmit: func(rol ReadableLogRecord) {
+		if rol.EventName() == "old.name" {
+			rol.(ReadWriteLogRecord).SetEventName("new.name")
+		}
+	}}
+	processor := &testProcessor{}
+	logger := NewLoggerProvider(
+		WithLogRecordProcessor(rename),
+		WithLogRecordProcessor(processor),
+	).Logger("test")
+
+	logs.EmitEvent(context.Background(), logger, "session.start", logs.String("user", "ann"))
+	logs.EmitEvent(context.Background(), logger, "old.name")
+
+	require.Len(t, processor.records, 2)
+	assert.Equal(t, "session.start", processor.records[0].EventName())
+	assert.Equal(t, []logs.KeyValue{logs.String("user", "ann")}, processor.records[0].StructuredAttributes())
+	assert.Equal(t, "new.name", processor.records[1].EventName())
+}
+
+func TestLoggerEnabled(t *testing.T) {
+	ctx := context.Background()
+
+	logger := NewLoggerProvider().Logger("test")
+	assert.False(t, logger.Enabled(ctx, logs.EnabledParameters{Severity: logs.ERROR}))
+
+	logger = NewLoggerProvider(
+		WithLogRecordProcessor(&severityFilterProcessor{min: logs.WARN}),
+	).Logger("test")
+	assert.False(t, logger.Enabled(ctx, logs.EnabledParameters{Severity: logs.DEBUG}))
+	assert.True(t, logger.Enabled(ctx, logs.EnabledParameters{Severity: logs.WARN}))
+
+	logger = NewLoggerProvider(
+		WithLogRecordProcessor(&severityFilterProcessor{min: logs.WARN}),
+		WithLogRecordProcessor(&testProcessor{}),
+	).Logger("test")
+	assert.True(t, logger.Enabled(ctx, logs.EnabledParameters{Severity: logs.DEBUG}))
+}
+
+func TestLoggerInstrumentationScope(t *testing.T) {
+	processor := &testProcessor{}
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test",
+		logs.WithInstrumentationVersion("v0.1.0"),
+		logs.WithSchemaURL(semconv.SchemaURL),
+		logs.WithInstrumentationAttributes(attribute.String("key", "value")),
+	)
+
+	body := "body"
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{Body: &body}))
+
+	bridgeScope := instrumentation.Scope{Name: "bridge"}
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{Body: &body, InstrumentationScope: &bridgeScope}))
+
+	assert.Len(t, processor.records, 2)
+	assert.Equal(t, instrumentation.Scope{
+		Name:       "test",
+		Version:    "v0.1.0",
+		SchemaURL:  semconv.SchemaURL,
+		Attributes: attribute.NewSet(attribute.String("key", "value")),
+	}, *processor.records[0].InstrumentationScope())
+	assert.Equal(t, bridgeScope, *processor.records[1].InstrumentationScope())
+}
+
+func TestReadWriteLogRecordAttributes(t *testing.T) {
+	attributes := []attribute.KeyValue{attribute.String("a", "1"), attribute.String("b", "2")}
+	record := &exportableLogRecord{attributes: &attributes}
+
+	record.SetAttribute(attribute.String("a", "3"))
+	record.AddAttributes(attribute.String("c", "4"))
+	record.RemoveAttribute("b")
+
+	assert.Equal(t, []attribute.KeyValue{attribute.String("a", "1"), attribute.String("b", "2")}, attributes)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("a", "3"), attribute.String("c", "4")}, *record.Attributes())
+
+	clone := record.clone()
+	record.RemoveAttribute("c")
+	clone.AddAttributes(attribute.String("d", "5"))
+
+	assert.Equal(t, []attribute.KeyValue{attribute.String("a", "3")}, *record.Attributes())
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("a", "3"),
+		attribute.String("c", "4"),
+		attribute.String("d", "5"),
+	}, *clone.Attributes())
+
+	clone.SetAttributes(attribute.String("e", "6"))
+	assert.Equal(t, []attribute.KeyValue{attribute.String("e", "6")}, *clone.Attributes())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("a", "3")}, *record.Attributes())
+
+	empty := &exportableLogRecord{}
+	message := "message"
+	empty.RecordException(&message, nil, nil)
+	assert.Equal(t, []attribute.KeyValue{semconv.ExceptionMessage("message")}, *empty.Attributes())
+}
+
+func TestReadWriteLogRecordStructuredAttributes(t *testing.T) {
+	attributes := []attribute.KeyValue{attribute.String("a", "1")}
+	structured := []logs.KeyValue{
+		logs.Map("user", logs.String("name", "ann"), logs.Int("id", 7)),
+		logs.Slice("mixed", logs.StringValue("x"), logs.IntValue(1)),
+	}
+	record := &exportableLogRecord{attributes: &attributes, structuredAttributes: structured}
+
+	record.SetStructuredAttribute(logs.Bytes("a", []byte{1}))
+	record.AddStructuredAttributes(logs.Bool("b", true))
+	record.RemoveAttribute("mixed")
+
+	assert.Empty(t, *record.Attributes())
+	assert.Equal(t, []logs.KeyValue{
+		logs.Map("user", logs.String("name", "ann"), logs.Int("id", 7)),
+		logs.Bytes("a", []byte{1}),
+		logs.Bool("b", true),
+	}, record.StructuredAttributes())
+	assert.Len(t, structured, 2)
+	assert.Equal(t, "mixed", structured[1].Key)
+
+	clone := record.clone()
+	clone.SetAttribute(attribute.String("user", "bob"))
+	assert.Len(t, record.StructuredAttributes(), 3)
+	assert.Equal(t, []logs.KeyValue{logs.Bytes("a", []byte{1}), logs.Bool("b", true)}, clone.StructuredAttributes())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("user", "bob")}, *clone.Attributes())
+
+	clone.SetStructuredAttributes(logs.Int("c", 1))
+	assert.Equal(t, []logs.KeyValue{logs.Int("c", 1)}, clone.StructuredAttributes())
+	assert.Len(t, record.StructuredAttributes(), 3)
+}
+
+func TestLoggerProcessorsModifyRecord(t *testing.T) {
+	ts := time.Unix(1589932800, 0)
+	rewrite := funcProcessor{onEmit: func(rol ReadableLogRecord) {
+		rw := rol.(ReadWriteLogRecord)
+		rw.SetBody("rewritten")
+		rw.SetSeverityNumber(logs.WARN)
+		rw.SetSeverityText("WARN")
+		rw.SetTimestamp(ts)
+		rw.SetAttribute(attribute.String("key", "new"))
+	}}
+	processor := &testProcessor{}
+	logger := NewLoggerProvider(
+		WithLogRecordProcessor(rewrite),
+		WithLogRecordProcessor(processor),
+	).Logger("test")
+
+	body := "body"
+	sn := logs.INFO
+	attributes := []attribute.KeyValue{attribute.String("key", "old")}
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		Body:           &body,
+		SeverityNumber: &sn,
+		Attributes:     &attributes,
+	}))
+
+	assert.Len(t, processor.records, 1)
+	record := processor.records[0]
+	assert.Equal(t, "rewritten", record.Body())
+	assert.Equal(t, logs.WARN, *record.SeverityNumber())
+	assert.Equal(t, "WARN", *record.SeverityText())
+	assert.Equal(t, ts, *record.Timestamp())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("key", "new")}, *record.Attributes())
+
+	assert.Equal(t, logs.INFO, sn)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("key", "old")}, attributes)
+}
+
+func TestLoggerKeepsBodyAny(t *testing.T) {
+	type event struct{ ID int }
+	processor := &testProcessor{}
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	body := &event{ID: 1}
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{BodyAny: body}))
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{BodyValue: logs.StringValue("value")}))
+
+	require.Len(t, processor.records, 2)
+	assert.Same(t, body, processor.records[0].Body())
+	assert.Equal(t, logs.MapValue(logs.Int64("ID", 1)), processor.records[0].BodyValue())
+	assert.Equal(t, "value", processor.records[1].Body())
+
+	clone := processor.records[0].(*exportableLogRecord).clone()
+	assert.Same(t, body, clone.Body())
+	clone.SetBodyValue(logs.StringValue("replaced"))
+	assert.Equal(t, "replaced", clone.Body())
+}
+
+func TestLoggerConfigurator(t *testing.T) {
+	next := &testProcessor{}
+	var calls int
+	provider := NewLoggerProvider(
+		WithLogRecordProcessor(next),
+		WithLoggerConfigurator(func(is instrumentation.Scope) LoggerConfig {
+			calls++
+			switch is.Name {
+			case "grpc":
+				return LoggerConfig{Disabled: true}
+			case "kafka":
+				return LoggerConfig{MinSeverity: logs.WARN}
+			}
+			return LoggerConfig{}
+		}),
+	)
+	app, grpc, kafka := provider.Logger("app"), provider.Logger("grpc"), provider.Logger("kafka")
+	assert.Equal(t, 3, calls)
+
+	for _, l := range []logs.Logger{app, grpc, kafka} {
+		emitSeverity(l, logs.INFO)
+		emitSeverity(l, logs.ERROR)
+		l.Emit(logs.NewLogRecord(logs.LogRecordConfig{EventName: "event"}))
+	}
+	assert.Len(t, next.records, 5)
+	assert.Equal(t, 3, calls)
+	assert.False(t, grpc.Enabled(context.Background(), logs.EnabledParameters{Severity: logs.ERROR}))
+	assert.False(t, kafka.Enabled(context.Background(), logs.EnabledParameters{Severity: logs.INFO}))
+	assert.True(t, kafka.Enabled(context.Background(), logs.EnabledParameters{Severity: logs.WARN}))
+
+	// The Loggers created before use the new configurator.
+	provider.SetLoggerConfigurator(func(is instrumentation.Scope) LoggerConfig {
+		return LoggerConfig{Disabled: is.Name == "app"}
+	})
+	emitSeverity(app, logs.ERROR)
+	emitSeverity(grpc, logs.INFO)
+	emitSeverity(kafka, logs.INFO)
+	assert.Len(t, next.records, 7)
+	assert.False(t, app.Enabled(context.Background(), logs.EnabledParameters{}))
+
+	provider.SetLoggerConfigurator(nil)
+	assert.True(t, app.Enabled(context.Background(), logs.EnabledParameters{}))
+}
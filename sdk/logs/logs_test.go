@@ -2,6 +2,7 @@ package logs
 
 import (
 	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	"sync"
 )
 
@@ -30,3 +31,51 @@ func (te *testExporter) Export(ctx context.Context, logs []ReadableLogRecord) er
 func (te *testExporter) Shutdown(ctx context.Context) error {
 	return nil
 }
+
+type testProcessor struct {
+	mu      sync.Mutex
+	records []ReadableLogRecord
+	ctxs    []context.Context
+}
+
+var _ ContextLogRecordProcessor = (*testProcessor)(nil)
+
+func (tp *testProcessor) OnEmit(rol ReadableLogRecord) {
+	tp.OnEmitContext(context.Background(), rol)
+}
+
+func (tp *testProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.records = append(tp.records, rol)
+	tp.ctxs = append(tp.ctxs, ctx)
+}
+
+func (tp *testProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (tp *testProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+type severityFilterProcessor struct {
+	testProcessor
+	min logs.SeverityNumber
+}
+
+var _ FilterProcessor = (*severityFilterProcessor)(nil)
+
+func (p *severityFilterProcessor) Enabled(ctx context.Context, param logs.EnabledParameters) bool {
+	return param.Severity >= p.min
+}
+
+type funcProcessor struct {
+	onEmit func(rol ReadableLogRecord)
+}
+
+func (fp funcProcessor) OnEmit(rol ReadableLogRecord) { fp.onEmit(rol) }
+
+func (fp funcProcessor) Shutdown(ctx context.Context) error { return nil }
+
+func (fp funcProcessor) ForceFlush(ctx context.Context) error { return nil }
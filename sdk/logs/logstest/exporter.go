@@ -0,0 +1,69 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logstest
+
+import (
+	"context"
+	logssdk "github.com/agoda-com/opentelemetry-logs-go/sdk/logs"
+	"sync"
+)
+
+var _ logssdk.LogRecordExporter = (*InMemoryExporter)(nil)
+
+// InMemoryExporter is an exporter that stores all received log records
+// in-memory.
+type InMemoryExporter struct {
+	mu sync.Mutex
+	ls LogRecordStubs
+}
+
+// NewInMemoryExporter returns a new InMemoryExporter.
+func NewInMemoryExporter() *InMemoryExporter {
+	return new(InMemoryExporter)
+}
+
+// Export stores the provided log records.
+func (e *InMemoryExporter) Export(_ context.Context, lrs []logssdk.ReadableLogRecord) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, lr := range lrs {
+		e.ls = append(e.ls, LogRecordStubFromReadableLogRecord(lr))
+	}
+	return nil
+}
+
+// Shutdown stops the exporter by clearing the log records held in memory.
+func (e *InMemoryExporter) Shutdown(context.Context) error {
+	e.Reset()
+	return nil
+}
+
+// Reset the current in-memory storage.
+func (e *InMemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ls = nil
+}
+
+// GetLogRecords returns the current in-memory stored log records.
+func (e *InMemoryExporter) GetLogRecords() LogRecordStubs {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ret := make(LogRecordStubs, len(e.ls))
+	copy(ret, e.ls)
+	return ret
+}
@@ -28,17 +28,23 @@ func (l LogRecordStubs) Snapshots() []logssdk.ReadableLogRecord {
 
 // LogRecordStub is a stand-in for a LogRecord.
 type LogRecordStub struct {
-	Timestamp            *time.Time
-	ObservedTimestamp    time.Time
-	TraceId              *trace.TraceID
-	SpanId               *trace.SpanID
-	TraceFlags           *trace.TraceFlags
-	SeverityText         *string
-	SeverityNumber       *logs.SeverityNumber
+	Timestamp         *time.Time
+	ObservedTimestamp time.Time
+	TraceId           *trace.TraceID
+	SpanId            *trace.SpanID
+	TraceFlags        *trace.TraceFlags
+	SeverityText      *string
+	SeverityNumber    *logs.SeverityNumber
+	// Body is the body as a Go value, it is converted with logs.ValueOf when
+	// BodyValue is empty.
 	Body                 any
+	BodyValue            logs.Value
 	Resource             *resource.Resource
 	InstrumentationScope *instrumentation.Scope
 	Attributes           *[]attribute.KeyValue
+	StructuredAttributes []logs.KeyValue
+	EventName            string
+	DroppedAttributes    int
 }
 
 // LogRecordStubFromReadableLogRecord returns a LogRecordStub populated from rl.
@@ -55,14 +61,22 @@ func LogRecordStubFromReadableLogRecord(rl logssdk.ReadableLogRecord) LogRecordS
 		SeverityText:         rl.SeverityText(),
 		SeverityNumber:       rl.SeverityNumber(),
 		Body:                 rl.Body(),
+		BodyValue:            rl.BodyValue(),
 		Resource:             rl.Resource(),
 		InstrumentationScope: rl.InstrumentationScope(),
 		Attributes:           rl.Attributes(),
+		StructuredAttributes: rl.StructuredAttributes(),
+		EventName:            rl.EventName(),
+		DroppedAttributes:    rl.DroppedAttributesCount(),
 	}
 }
 
 // Snapshot returns a read-only copy of the LogRecordStub.
 func (s LogRecordStub) Snapshot() logssdk.ReadableLogRecord {
+	body := s.BodyValue
+	if body.Empty() {
+		body = logs.ValueOf(s.Body)
+	}
 	return &logRecordSnapshot{
 		timestamp:            s.Timestamp,
 		observedTimestamp:    s.ObservedTimestamp,
@@ -71,10 +85,13 @@ func (s LogRecordStub) Snapshot() logssdk.ReadableLogRecord {
 		traceFlags:           s.TraceFlags,
 		severityText:         s.SeverityText,
 		severityNumber:       s.SeverityNumber,
-		body:                 s.Body,
+		body:                 body,
 		resource:             s.Resource,
 		instrumentationScope: s.InstrumentationScope,
 		attributes:           s.Attributes,
+		structuredAttributes: s.StructuredAttributes,
+		eventName:            s.EventName,
+		droppedAttributes:    s.DroppedAttributes,
 	}
 }
 
@@ -88,10 +105,13 @@ type logRecordSnapshot struct {
 	traceFlags           *trace.TraceFlags
 	severityText         *string
 	severityNumber       *logs.SeverityNumber
-	body                 any
+	body                 logs.Value
 	resource             *resource.Resource
 	instrumentationScope *instrumentation.Scope
 	attributes           *[]attribute.KeyValue
+	structuredAttributes []logs.KeyValue
+	eventName            string
+	droppedAttributes    int
 }
 
 func (r *logRecordSnapshot) Timestamp() *time.Time         { return r.timestamp }
@@ -104,6 +124,14 @@ func (r *logRecordSnapshot) InstrumentationScope() *instrumentation.Scope {
 }
 func (r *logRecordSnapshot) SeverityText() *string                { return r.severityText }
 func (r *logRecordSnapshot) SeverityNumber() *logs.SeverityNumber { return r.severityNumber }
-func (r *logRecordSnapshot) Body() any                            { return r.body }
+func (r *logRecordSnapshot) BodyValue() logs.Value                { return r.body }
+func (r *logRecordSnapshot) Body() any                            { return r.body.Any() }
 func (r *logRecordSnapshot) Resource() *resource.Resource         { return r.resource }
 func (r *logRecordSnapshot) Attributes() *[]attribute.KeyValue    { return r.attributes }
+func (r *logRecordSnapshot) StructuredAttributes() []logs.KeyValue {
+	return r.structuredAttributes
+}
+func (r *logRecordSnapshot) EventName() string { return r.eventName }
+func (r *logRecordSnapshot) DroppedAttributesCount() int {
+	return r.droppedAttributes
+}
@@ -22,10 +22,14 @@ import (
 	"github.com/agoda-com/opentelemetry-logs-go/internal/global"
 	"github.com/agoda-com/opentelemetry-logs-go/logs"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"reflect"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -37,6 +41,10 @@ type loggerProviderConfig struct {
 	processors []LogRecordProcessor
 	// resource contains attributes representing an entity that produces telemetry.
 	resource *resource.Resource
+	// limits are applied to the log records.
+	limits LogRecordLimits
+	// configurator returns the LoggerConfig of each Logger.
+	configurator LoggerConfigurator
 }
 
 // LoggerProviderOption configures a LoggerProvider.
@@ -94,15 +102,17 @@ func WithResource(r *resource.Resource) LoggerProviderOption {
 // see https://opentelemetry.io/docs/specs/otel/logs/bridge-api/#loggerprovider
 type LoggerProvider struct {
 	mu          sync.Mutex
-	namedLogger map[instrumentation.Scope]*logger
+	namedLogger map[loggerKey]*logger
 	//cfg loggerProviderConfig
 
 	logProcessors atomic.Pointer[logRecordProcessorStates]
 	isShutdown    atomic.Bool
+	configurator  atomic.Pointer[loggerConfigurator]
 
 	// These fields are not protected by the lock mu. They are assumed to be
 	// immutable after creation of the LoggerProvider.
 	resource *resource.Resource
+	limits   LogRecordLimits
 }
 
 var _ logs.LoggerProvider = &LoggerProvider{}
@@ -120,9 +130,10 @@ func (lp *LoggerProvider) Logger(name string, opts ...logs.LoggerOption) logs.Lo
 	}
 
 	is := instrumentation.Scope{
-		Name:      name,
-		Version:   c.InstrumentationVersion(),
-		SchemaURL: c.SchemaURL(),
+		Name:       name,
+		Version:    c.InstrumentationVersion(),
+		SchemaURL:  c.SchemaURL(),
+		Attributes: c.InstrumentationAttributes(),
 	}
 
 	t, ok := func() (logs.Logger, bool) {
@@ -134,12 +145,14 @@ func (lp *LoggerProvider) Logger(name string, opts ...logs.LoggerOption) logs.Lo
 			return logs.NewNoopLoggerProvider().Logger(name, opts...), true
 		}
 
-		t, ok := lp.namedLogger[is]
+		key := newLoggerKey(is)
+		t, ok := lp.namedLogger[key]
 		if !ok {
 			t = &logger{
 				provider:             lp,
 				instrumentationScope: is,
 			}
+			lp.namedLogger[key] = t
 		}
 		return t, ok
 	}()
@@ -150,6 +163,7 @@ func (lp *LoggerProvider) Logger(name string, opts ...logs.LoggerOption) logs.Lo
 		// - Logging code may be instrumented with logging and deadlock because it could try
 		//   acquiring the same non-reentrant mutex.
 		global.Info("Logger created", "name", name, "version", is.Version, "schemaURL", is.SchemaURL)
+		t.(*logger).config()
 
 	}
 	return t
@@ -169,8 +183,9 @@ func NewLoggerProvider(opts ...LoggerProviderOption) *LoggerProvider {
 	o = ensureValidLoggerProviderConfig(o)
 
 	lp := &LoggerProvider{
-		namedLogger: make(map[instrumentation.Scope]*logger),
+		namedLogger: make(map[loggerKey]*logger),
 		resource:    o.resource,
+		limits:      o.limits,
 	}
 
 	global.Info("LoggerProvider created", "config", o)
@@ -180,16 +195,152 @@ func NewLoggerProvider(opts ...LoggerProviderOption) *LoggerProvider {
 		lrpss = append(lrpss, newLogsProcessorState(lrp))
 	}
 	lp.logProcessors.Store(&lrpss)
+	lp.configurator.Store(&loggerConfigurator{f: o.configurator})
 
 	return lp
 
 }
 
+// loggerKey identifies the Logger of an instrumentation scope.
+type loggerKey struct {
+	name      string
+	version   string
+	schemaURL string
+	attrs     attribute.Distinct
+}
+
+func newLoggerKey(is instrumentation.Scope) loggerKey {
+	return loggerKey{
+		name:      is.Name,
+		version:   is.Version,
+		schemaURL: is.SchemaURL,
+		attrs:     is.Attributes.Equivalent(),
+	}
+}
+
+// Scopes returns the instrumentation scopes of the Loggers created by the
+// LoggerProvider, sorted by name, version and schema URL.
+func (p *LoggerProvider) Scopes() []instrumentation.Scope {
+	p.mu.Lock()
+	scopes := make([]instrumentation.Scope, 0, len(p.namedLogger))
+	for _, l := range p.namedLogger {
+		scopes = append(scopes, l.instrumentationScope)
+	}
+	p.mu.Unlock()
+
+	sort.Slice(scopes, func(i, j int) bool {
+		a, b := scopes[i], scopes[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		if a.SchemaURL != b.SchemaURL {
+			return a.SchemaURL < b.SchemaURL
+		}
+		return a.Attributes.Encoded(attribute.DefaultEncoder()) < b.Attributes.Encoded(attribute.DefaultEncoder())
+	})
+	return scopes
+}
+
 func (p *LoggerProvider) getLogRecordProcessorStates() logRecordProcessorStates {
 	return *(p.logProcessors.Load())
 }
 
-func (p LoggerProvider) Shutdown(ctx context.Context) error {
+// SetLoggerConfigurator replaces the LoggerConfigurator, see
+// WithLoggerConfigurator. The Loggers created before are configured again
+// with it.
+func (p *LoggerProvider) SetLoggerConfigurator(configurator LoggerConfigurator) {
+	p.configurator.Store(&loggerConfigurator{f: configurator})
+}
+
+// RegisterLogRecordProcessor adds the given LogRecordProcessor to the list of
+// LogRecordProcessors. It receives the log records emitted after it is
+// registered.
+func (p *LoggerProvider) RegisterLogRecordProcessor(lrp LogRecordProcessor) {
+	// This check prevents calls during a shutdown.
+	if p.isShutdown.Load() {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// This check prevents calls after a shutdown.
+	if p.isShutdown.Load() {
+		return
+	}
+
+	current := p.getLogRecordProcessorStates()
+	lrpss := make(logRecordProcessorStates, 0, len(current)+1)
+	lrpss = append(lrpss, current...)
+	lrpss = append(lrpss, newLogsProcessorState(lrp))
+	p.logProcessors.Store(&lrpss)
+}
+
+// UnregisterLogRecordProcessor removes the given LogRecordProcessor from the
+// list of LogRecordProcessors and shuts it down, waiting at most
+// DefaultExportTimeout milliseconds. Errors of the shutdown are passed to the
+// global error handler.
+//
+// Processors of types that are not comparable, such as structs holding a
+// func, cannot be told apart and are not removed.
+func (p *LoggerProvider) UnregisterLogRecordProcessor(lrp LogRecordProcessor) {
+	lrps := p.removeLogRecordProcessor(lrp)
+	if lrps == nil {
+		return
+	}
+
+	// The processor is shut down without holding the lock, so a slow
+	// exporter does not block the other calls to the LoggerProvider.
+	lrps.state.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultExportTimeout*time.Millisecond)
+		defer cancel()
+		if err := lrp.Shutdown(ctx); err != nil {
+			otel.Handle(err)
+		}
+	})
+}
+
+// removeLogRecordProcessor removes lrp from the list of LogRecordProcessors
+// and returns its state, or nil if it is not registered.
+func (p *LoggerProvider) removeLogRecordProcessor(lrp LogRecordProcessor) *logRecordProcessorState {
+	// Processors of types that are not comparable cannot be compared.
+	if lrp == nil || !reflect.TypeOf(lrp).Comparable() {
+		return nil
+	}
+	// This check prevents calls during a shutdown.
+	if p.isShutdown.Load() {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// This check prevents calls after a shutdown.
+	if p.isShutdown.Load() {
+		return nil
+	}
+
+	current := p.getLogRecordProcessorStates()
+	idx := -1
+	for i, lrps := range current {
+		if lrps.lp == lrp {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+
+	// Log records are no longer passed to the processor before it is shut
+	// down.
+	lrpss := make(logRecordProcessorStates, 0, len(current)-1)
+	lrpss = append(lrpss, current[:idx]...)
+	lrpss = append(lrpss, current[idx+1:]...)
+	p.logProcessors.Store(&lrpss)
+	return current[idx]
+}
+
+func (p *LoggerProvider) Shutdown(ctx context.Context) error {
 	// This check prevents deadlocks in case of recursive shutdown.
 	if p.isShutdown.Load() {
 		return nil
@@ -260,6 +411,11 @@ func applyLoggerProviderEnvConfigs(cfg loggerProviderConfig) loggerProviderConfi
 func loggerProviderOptionsFromEnv() []LoggerProviderOption {
 	var opts []LoggerProviderOption
 
+	opts = append(opts, loggerProviderOptionFunc(func(cfg loggerProviderConfig) loggerProviderConfig {
+		cfg.limits = NewLogRecordLimits()
+		return cfg
+	}))
+
 	return opts
 }
 
@@ -17,11 +17,15 @@ limitations under the License.
 package logs
 
 import (
+	"context"
 	//	"github.com/agoda-com/opentelemetry-logs-go/exporters/otlp/otlplogs/otlplogshttp"
 	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
 	"testing"
+	"time"
 )
 
 const (
@@ -60,3 +64,110 @@ func TestLogsProvider(t *testing.T) {
 	batchOtlpLogger.Emit(logRecord)
 
 }
+
+func TestLoggerProviderRegisterLogRecordProcessor(t *testing.T) {
+	first, second := &shutdownCountingProcessor{}, &shutdownCountingProcessor{}
+	provider := NewLoggerProvider(WithLogRecordProcessor(first))
+	logger := provider.Logger("test")
+
+	emitSeverity(logger, logs.INFO)
+	provider.RegisterLogRecordProcessor(second)
+	emitSeverity(logger, logs.INFO)
+	assert.Len(t, first.records, 2)
+	assert.Len(t, second.records, 1)
+
+	provider.UnregisterLogRecordProcessor(second)
+	provider.UnregisterLogRecordProcessor(second)
+	emitSeverity(logger, logs.INFO)
+	assert.Len(t, first.records, 3)
+	assert.Len(t, second.records, 1)
+	assert.Equal(t, 1, second.shutdowns)
+
+	provider.UnregisterLogRecordProcessor(first)
+	assert.Equal(t, 1, first.shutdowns)
+	assert.False(t, logger.Enabled(context.Background(), logs.EnabledParameters{}))
+
+	// The processors registered again are new registrations.
+	provider.RegisterLogRecordProcessor(first)
+	require.NoError(t, provider.Shutdown(context.Background()))
+	assert.Equal(t, 2, first.shutdowns)
+
+	provider.RegisterLogRecordProcessor(second)
+	assert.Empty(t, provider.getLogRecordProcessorStates())
+}
+
+func TestLoggerProviderUnregisterNotComparable(t *testing.T) {
+	var records int
+	processor := funcProcessor{onEmit: func(ReadableLogRecord) { records++ }}
+	provider := NewLoggerProvider()
+	logger := provider.Logger("test")
+
+	provider.RegisterLogRecordProcessor(processor)
+	assert.NotPanics(t, func() { provider.UnregisterLogRecordProcessor(processor) })
+	emitSeverity(logger, logs.INFO)
+	assert.Equal(t, 1, records)
+
+	// Other processors are still found next to it.
+	counting := &shutdownCountingProcessor{}
+	provider.RegisterLogRecordProcessor(counting)
+	provider.UnregisterLogRecordProcessor(counting)
+	assert.Equal(t, 1, counting.shutdowns)
+	assert.Len(t, provider.getLogRecordProcessorStates(), 1)
+}
+
+// loggerShutdownProcessor creates a Logger of its provider when it is shut
+// down.
+type loggerShutdownProcessor struct {
+	shutdownCountingProcessor
+	provider *LoggerProvider
+	deadline bool
+}
+
+func (p *loggerShutdownProcessor) Shutdown(ctx context.Context) error {
+	p.provider.Logger("during shutdown")
+	_, p.deadline = ctx.Deadline()
+	return p.shutdownCountingProcessor.Shutdown(ctx)
+}
+
+func TestLoggerProviderUnregisterShutdownUnlocked(t *testing.T) {
+	provider := NewLoggerProvider()
+	processor := &loggerShutdownProcessor{provider: provider}
+	provider.RegisterLogRecordProcessor(processor)
+
+	done := make(chan struct{})
+	go func() {
+		provider.UnregisterLogRecordProcessor(processor)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("UnregisterLogRecordProcessor holds the lock during Shutdown")
+	}
+	assert.Equal(t, 1, processor.shutdowns)
+	assert.True(t, processor.deadline)
+}
+
+func TestLoggerProviderLoggerRegistry(t *testing.T) {
+	provider := NewLoggerProvider()
+
+	a := provider.Logger("a", logs.WithInstrumentationVersion("1"))
+	assert.Same(t, a, provider.Logger("a", logs.WithInstrumentationVersion("1")))
+	assert.NotSame(t, a, provider.Logger("a", logs.WithInstrumentationVersion("2")))
+	assert.NotSame(t, a, provider.Logger("a", logs.WithInstrumentationVersion("1"), logs.WithSchemaURL(semconv.SchemaURL)))
+
+	withAttrs := provider.Logger("b", logs.WithInstrumentationAttributes(semconv.HostName("x"), semconv.ServiceName("y")))
+	assert.Same(t, withAttrs, provider.Logger("b", logs.WithInstrumentationAttributes(semconv.ServiceName("y"), semconv.HostName("x"))))
+	assert.NotSame(t, withAttrs, provider.Logger("b", logs.WithInstrumentationAttributes(semconv.HostName("z"))))
+	assert.Same(t, provider.Logger(""), provider.Logger(defaultLoggerName))
+
+	scopes := provider.Scopes()
+	require.Len(t, scopes, 6)
+	assert.Equal(t, "a", scopes[0].Name)
+	assert.Equal(t, "1", scopes[0].Version)
+	assert.Equal(t, "", scopes[0].SchemaURL)
+	assert.Equal(t, semconv.SchemaURL, scopes[1].SchemaURL)
+	assert.Equal(t, "2", scopes[2].Version)
+	assert.Equal(t, "b", scopes[3].Name)
+	assert.Equal(t, defaultLoggerName, scopes[5].Name)
+}
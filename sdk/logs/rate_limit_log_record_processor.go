@@ -0,0 +1,389 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"fmt"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SuppressedCountKey is the attribute key of the number of log records a
+// summary log record of a RateLimitProcessor stands for.
+const SuppressedCountKey = attribute.Key("log.record.suppressed_count")
+
+// SuppressedByKey is the attribute key of the limit that suppressed the log
+// records a summary log record of a RateLimitProcessor stands for: "global",
+// "scope" or the severity range of the limit, such as "severity:TRACE-INFO4".
+const SuppressedByKey = attribute.Key("log.record.suppressed_by")
+
+// RateLimitProcessorOption configures a RateLimitProcessor.
+type RateLimitProcessorOption func(cfg *rateLimitConfig)
+
+type rateLimitConfig struct {
+	global     *rateLimit
+	scope      *rateLimit
+	severities []severityRateLimit
+	// summaryInterval is the interval of the summary log records, 0 if
+	// suppressed log records are only dropped.
+	summaryInterval time.Duration
+}
+
+type rateLimit struct {
+	rate  float64
+	burst int
+}
+
+type severityRateLimit struct {
+	min, max logs.SeverityNumber
+	limit    rateLimit
+}
+
+// WithGlobalRateLimit limits all log records to rate per second, allowing
+// bursts of up to burst log records.
+func WithGlobalRateLimit(rate float64, burst int) RateLimitProcessorOption {
+	return func(cfg *rateLimitConfig) {
+		cfg.global = &rateLimit{rate: rate, burst: burst}
+	}
+}
+
+// WithScopeRateLimit limits the log records of each instrumentation scope to
+// rate per second, allowing bursts of up to burst log records. Every scope
+// name has a limit of its own.
+func WithScopeRateLimit(rate float64, burst int) RateLimitProcessorOption {
+	return func(cfg *rateLimitConfig) {
+		cfg.scope = &rateLimit{rate: rate, burst: burst}
+	}
+}
+
+// WithSeverityRateLimit limits the log records with a severity from min to
+// max, inclusive, to rate per second, allowing bursts of up to burst log
+// records. It can be used several times for different severity ranges.
+func WithSeverityRateLimit(min, max logs.SeverityNumber, rate float64, burst int) RateLimitProcessorOption {
+	return func(cfg *rateLimitConfig) {
+		cfg.severities = append(cfg.severities, severityRateLimit{
+			min:   min,
+			max:   max,
+			limit: rateLimit{rate: rate, burst: burst},
+		})
+	}
+}
+
+// WithSuppressionSummary makes the processor pass a summary log record on for
+// each limit and instrumentation scope whose log records were suppressed,
+// every interval, on ForceFlush and on Shutdown. The summary log records have
+// WARN severity, a body such as "42 log records suppressed" and the
+// SuppressedCountKey and SuppressedByKey attributes.
+func WithSuppressionSummary(interval time.Duration) RateLimitProcessorOption {
+	return func(cfg *rateLimitConfig) {
+		cfg.summaryInterval = interval
+	}
+}
+
+// RateLimitProcessor is a LogRecordProcessor that limits the rate of the log
+// records passed on to another LogRecordProcessor using token buckets. A log
+// record is passed on only if every limit it falls under, global, of its
+// instrumentation scope and of its severity range, allows it.
+//
+// Log records above a limit are dropped and counted, see Suppressed and
+// WithSuppressionSummary.
+type RateLimitProcessor struct {
+	next LogRecordProcessor
+	cfg  rateLimitConfig
+	now  func() time.Time
+
+	mu         sync.Mutex
+	global     *tokenBucket
+	scopes     map[string]*tokenBucket
+	severities []*tokenBucket
+	// pending holds the log records suppressed per limit and scope name
+	// since the last summary.
+	pending map[suppressionKey]*suppression
+
+	suppressed atomic.Uint64
+
+	// summaryMu serializes the summaries, so none is passed on after
+	// Shutdown returns.
+	summaryMu  sync.Mutex
+	isShutdown bool
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	stopped  chan struct{}
+}
+
+var _ ContextLogRecordProcessor = (*RateLimitProcessor)(nil)
+
+type suppressionKey struct {
+	limit string
+	scope string
+}
+
+type suppression struct {
+	count    int64
+	scope    *instrumentation.Scope
+	resource *resource.Resource
+}
+
+// NewRateLimitProcessor returns a RateLimitProcessor that passes the log
+// records within the limits on to next. Without limits every log record is
+// passed on.
+func NewRateLimitProcessor(next LogRecordProcessor, opts ...RateLimitProcessorOption) *RateLimitProcessor {
+	var cfg rateLimitConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	p := &RateLimitProcessor{
+		next:    next,
+		cfg:     cfg,
+		now:     time.Now,
+		scopes:  make(map[string]*tokenBucket),
+		pending: make(map[suppressionKey]*suppression),
+		stopCh:  make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if cfg.summaryInterval > 0 {
+		go p.summarize()
+	} else {
+		close(p.stopped)
+	}
+	return p
+}
+
+// Suppressed returns the number of log records that were suppressed.
+func (p *RateLimitProcessor) Suppressed() uint64 {
+	return p.suppressed.Load()
+}
+
+// OnEmit passes rol on if it is within the limits.
+func (p *RateLimitProcessor) OnEmit(rol ReadableLogRecord) {
+	p.OnEmitContext(context.Background(), rol)
+}
+
+// OnEmitContext passes rol on within ctx if it is within the limits.
+func (p *RateLimitProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	if !p.allow(rol) {
+		p.suppressed.Add(1)
+		return
+	}
+	onEmit(ctx, p.next, rol)
+}
+
+// Shutdown stops the summaries, passes the last summary log records on and
+// shuts the wrapped processor down. No summary log record is passed on after
+// Shutdown returns.
+func (p *RateLimitProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stopCh) })
+	var err error
+	select {
+	case <-p.stopped:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	p.summaryMu.Lock()
+	if err == nil && !p.isShutdown {
+		p.emitSummaries()
+	}
+	p.isShutdown = true
+	p.summaryMu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush passes the pending summary log records on and flushes the
+// wrapped processor. Once the processor is shut down, no summary log record
+// is passed on.
+func (p *RateLimitProcessor) ForceFlush(ctx context.Context) error {
+	p.flushSummaries()
+	return p.next.ForceFlush(ctx)
+}
+
+// allow reports whether rol is within the limits, taking a token from every
+// bucket it falls under if so.
+func (p *RateLimitProcessor) allow(rol ReadableLogRecord) bool {
+	var name string
+	is := rol.InstrumentationScope()
+	if is != nil {
+		name = is.Name
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+
+	// The buckets are created on first use, so they start full.
+	if p.severities == nil {
+		if p.cfg.global != nil {
+			p.global = newTokenBucket("global", *p.cfg.global, now)
+		}
+		p.severities = make([]*tokenBucket, len(p.cfg.severities))
+		for i, s := range p.cfg.severities {
+			name := "severity:" + s.min.String() + "-" + s.max.String()
+			p.severities[i] = newTokenBucket(name, s.limit, now)
+		}
+	}
+
+	buckets := make([]*tokenBucket, 0, 3)
+	if p.global != nil {
+		buckets = append(buckets, p.global)
+	}
+	if p.cfg.scope != nil {
+		b, ok := p.scopes[name]
+		if !ok {
+			b = newTokenBucket("scope", *p.cfg.scope, now)
+			p.scopes[name] = b
+		}
+		buckets = append(buckets, b)
+	}
+	if sn := rol.SeverityNumber(); sn != nil {
+		for i, s := range p.cfg.severities {
+			if *sn >= s.min && *sn <= s.max {
+				buckets = append(buckets, p.severities[i])
+			}
+		}
+	}
+
+	for _, b := range buckets {
+		if !b.available(now) {
+			if p.cfg.summaryInterval > 0 {
+				key := suppressionKey{limit: b.name, scope: name}
+				s, ok := p.pending[key]
+				if !ok {
+					s = &suppression{}
+					p.pending[key] = s
+				}
+				s.count++
+				s.scope, s.resource = is, rol.Resource()
+			}
+			return false
+		}
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return true
+}
+
+// summarize passes the summary log records on every summary interval until
+// the processor is shut down.
+func (p *RateLimitProcessor) summarize() {
+	defer close(p.stopped)
+	ticker := time.NewTicker(p.cfg.summaryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.flushSummaries()
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+// flushSummaries passes the summary log records on unless the processor is
+// shut down.
+func (p *RateLimitProcessor) flushSummaries() {
+	p.summaryMu.Lock()
+	defer p.summaryMu.Unlock()
+	if !p.isShutdown {
+		p.emitSummaries()
+	}
+}
+
+// emitSummaries passes a summary log record on for each limit and scope whose
+// log records were suppressed since the last summary. summaryMu must be held.
+func (p *RateLimitProcessor) emitSummaries() {
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = make(map[suppressionKey]*suppression)
+	now := p.now()
+	p.mu.Unlock()
+
+	keys := make([]suppressionKey, 0, len(pending))
+	for key := range pending {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].limit != keys[j].limit {
+			return keys[i].limit < keys[j].limit
+		}
+		return keys[i].scope < keys[j].scope
+	})
+
+	for _, key := range keys {
+		s := pending[key]
+		sn, st := logs.WARN, logs.WARN.Name()
+		attrs := []attribute.KeyValue{
+			SuppressedCountKey.Int64(s.count),
+			SuppressedByKey.String(key.limit),
+		}
+		onEmit(context.Background(), p.next, &exportableLogRecord{
+			timestamp:            &now,
+			observedTimestamp:    now,
+			severityText:         &st,
+			severityNumber:       &sn,
+			body:                 logs.StringValue(suppressionMessage(s.count)),
+			resource:             s.resource,
+			instrumentationScope: s.scope,
+			attributes:           &attrs,
+			attributesOwned:      true,
+		})
+	}
+}
+
+// tokenBucket holds up to burst tokens and gains rate tokens per second.
+type tokenBucket struct {
+	// name is the limit the bucket belongs to, see SuppressedByKey.
+	name   string
+	limit  rateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(name string, limit rateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{name: name, limit: limit, tokens: float64(limit.burst), last: now}
+}
+
+// available refills the bucket and reports whether it holds a token.
+func (b *tokenBucket) available(now time.Time) bool {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.limit.rate
+		if max := float64(b.limit.burst); b.tokens > max {
+			b.tokens = max
+		}
+		b.last = now
+	}
+	return b.tokens >= 1
+}
+
+func suppressionMessage(count int64) string {
+	if count == 1 {
+		return "1 log record suppressed"
+	}
+	return fmt.Sprintf("%d log records suppressed", count)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestRateLimitProcessor(next LogRecordProcessor, opts ...RateLimitProcessorOption) (*RateLimitProcessor, *time.Time) {
+	p := NewRateLimitProcessor(next, opts...)
+	now := time.Unix(0, 0)
+	p.now = func() time.Time { return now }
+	return p, &now
+}
+
+func TestRateLimitProcessorGlobal(t *testing.T) {
+	next := &testProcessor{}
+	processor, now := newTestRateLimitProcessor(next, WithGlobalRateLimit(2, 3))
+	provider := NewLoggerProvider(WithLogRecordProcessor(processor))
+
+	for i := 0; i < 5; i++ {
+		emitSeverity(provider.Logger("a"), logs.INFO)
+	}
+	assert.Len(t, next.records, 3)
+	assert.Equal(t, uint64(2), processor.Suppressed())
+
+	*now = now.Add(time.Second)
+	for i := 0; i < 5; i++ {
+		emitSeverity(provider.Logger("b"), logs.INFO)
+	}
+	assert.Len(t, next.records, 5)
+	assert.Equal(t, uint64(5), processor.Suppressed())
+}
+
+func TestRateLimitProcessorScope(t *testing.T) {
+	next := &testProcessor{}
+	processor, _ := newTestRateLimitProcessor(next, WithScopeRateLimit(1, 2))
+	provider := NewLoggerProvider(WithLogRecordProcessor(processor))
+
+	for i := 0; i < 5; i++ {
+		emitSeverity(provider.Logger("noisy"), logs.INFO)
+	}
+	emitSeverity(provider.Logger("quiet"), logs.INFO)
+	require.Len(t, next.records, 3)
+	assert.Equal(t, "quiet", next.records[2].InstrumentationScope().Name)
+}
+
+func TestRateLimitProcessorSeverity(t *testing.T) {
+	next := &testProcessor{}
+	processor, _ := newTestRateLimitProcessor(next, WithSeverityRateLimit(logs.TRACE, logs.INFO4, 1, 1))
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	emitSeverity(logger, logs.DEBUG)
+	emitSeverity(logger, logs.INFO)
+	emitSeverity(logger, logs.ERROR)
+	emitSeverity(logger, logs.ERROR)
+	require.Len(t, next.records, 3)
+	assert.Equal(t, logs.DEBUG, *next.records[0].SeverityNumber())
+	assert.Equal(t, logs.ERROR, *next.records[1].SeverityNumber())
+}
+
+func TestRateLimitProcessorAllLimits(t *testing.T) {
+	next := &testProcessor{}
+	// A log record suppressed by one limit does not use the tokens of the
+	// others.
+	processor, _ := newTestRateLimitProcessor(next,
+		WithGlobalRateLimit(1, 2),
+		WithSeverityRateLimit(logs.DEBUG, logs.DEBUG4, 1, 1),
+	)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	emitSeverity(logger, logs.DEBUG)
+	emitSeverity(logger, logs.DEBUG)
+	emitSeverity(logger, logs.INFO)
+	emitSeverity(logger, logs.INFO)
+	assert.Len(t, next.records, 2)
+}
+
+func TestRateLimitProcessorSummary(t *testing.T) {
+	next := &testProcessor{}
+	processor, now := newTestRateLimitProcessor(next, WithScopeRateLimit(1, 1), WithSuppressionSummary(time.Hour))
+	provider := NewLoggerProvider(WithLogRecordProcessor(processor))
+
+	for i := 0; i < 4; i++ {
+		emitSeverity(provider.Logger("noisy"), logs.INFO)
+	}
+	require.NoError(t, processor.ForceFlush(context.Background()))
+	require.Len(t, next.records, 2)
+	summary := next.records[1]
+	assert.Equal(t, "3 log records suppressed", summary.BodyValue().AsString())
+	assert.Equal(t, logs.WARN, *summary.SeverityNumber())
+	assert.Equal(t, "noisy", summary.InstrumentationScope().Name)
+	assert.Equal(t, *now, *summary.Timestamp())
+	assert.Equal(t, []attribute.KeyValue{
+		SuppressedCountKey.Int64(3),
+		SuppressedByKey.String("scope"),
+	}, *summary.Attributes())
+
+	// Nothing was suppressed since.
+	require.NoError(t, processor.ForceFlush(context.Background()))
+	assert.Len(t, next.records, 2)
+
+	emitSeverity(provider.Logger("noisy"), logs.INFO)
+	require.NoError(t, processor.Shutdown(context.Background()))
+	require.Len(t, next.records, 3)
+	assert.Equal(t, "1 log record suppressed", next.records[2].BodyValue().AsString())
+}
+
+func TestRateLimitProcessorSummaryInterval(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewRateLimitProcessor(next, WithGlobalRateLimit(0, 0), WithSuppressionSummary(time.Millisecond))
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	emitSeverity(logger, logs.INFO)
+	assert.Eventually(t, func() bool {
+		next.mu.Lock()
+		defer next.mu.Unlock()
+		return len(next.records) == 1
+	}, time.Second, time.Millisecond)
+	require.NoError(t, processor.Shutdown(context.Background()))
+}
+
+func TestRateLimitProcessorSummaryPerLimit(t *testing.T) {
+	next := &testProcessor{}
+	processor, _ := newTestRateLimitProcessor(next,
+		WithGlobalRateLimit(0, 3),
+		WithSeverityRateLimit(logs.TRACE, logs.INFO4, 0, 1),
+		WithSuppressionSummary(time.Hour),
+	)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	emitSeverity(logger, logs.DEBUG)
+	emitSeverity(logger, logs.DEBUG)
+	emitSeverity(logger, logs.ERROR)
+	emitSeverity(logger, logs.ERROR)
+	emitSeverity(logger, logs.ERROR)
+	require.NoError(t, processor.ForceFlush(context.Background()))
+
+	require.Len(t, next.records, 5)
+	var suppressedBy []string
+	for _, r := range next.records[3:] {
+		for _, a := range *r.Attributes() {
+			if a.Key == SuppressedByKey {
+				suppressedBy = append(suppressedBy, a.Value.AsString())
+			}
+		}
+		assert.Equal(t, "1 log record suppressed", r.BodyValue().AsString())
+	}
+	assert.Equal(t, []string{"global", "severity:TRACE-INFO4"}, suppressedBy)
+}
+
+// orderProcessor records the order of the calls made to it.
+type orderProcessor struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (p *orderProcessor) OnEmit(ReadableLogRecord) { p.record("emit") }
+
+func (p *orderProcessor) Shutdown(context.Context) error {
+	p.record("shutdown")
+	return nil
+}
+
+func (p *orderProcessor) ForceFlush(context.Context) error { return nil }
+
+func (p *orderProcessor) record(call string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, call)
+}
+
+func (p *orderProcessor) recorded() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.calls...)
+}
+
+func TestRateLimitProcessorShutdownOrder(t *testing.T) {
+	next := &orderProcessor{}
+	processor := NewRateLimitProcessor(next, WithGlobalRateLimit(0, 0), WithSuppressionSummary(time.Millisecond))
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	// Suppressed log records keep the summaries coming until Shutdown.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			emitSeverity(logger, logs.INFO)
+		}
+	}()
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, processor.Shutdown(context.Background()))
+	<-done
+
+	calls := next.recorded()
+	require.NotEmpty(t, calls)
+	assert.Equal(t, "shutdown", calls[len(calls)-1])
+
+	// Neither the summary goroutine nor ForceFlush passes summaries on after
+	// Shutdown.
+	require.NoError(t, processor.ForceFlush(context.Background()))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, calls, next.recorded())
+}
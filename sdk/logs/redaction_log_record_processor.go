@@ -0,0 +1,473 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	"regexp"
+	"strings"
+)
+
+// RedactionCountKey is the attribute key of the number of redactions made in
+// a log record.
+const RedactionCountKey = attribute.Key("log.record.redaction_count")
+
+// RedactionMask replaces the redacted data unless a rule uses a replacement
+// of its own.
+const RedactionMask = "[REDACTED]"
+
+// RedactionRule redacts sensitive data from the values of log records.
+type RedactionRule interface {
+	// Redact returns value with the sensitive data redacted and the number of
+	// redactions made. key is the attribute key or map key value belongs to,
+	// or empty for a body that is not a map.
+	Redact(key, value string) (string, int)
+}
+
+// RedactionRuleFunc is a function that implements RedactionRule.
+type RedactionRuleFunc func(key, value string) (string, int)
+
+// Redact calls f.
+func (f RedactionRuleFunc) Redact(key, value string) (string, int) {
+	return f(key, value)
+}
+
+// RegexRedactionRule returns a RedactionRule that replaces the matches of re
+// with replacement, which can refer to submatches as in
+// regexp.Regexp.ReplaceAllString.
+func RegexRedactionRule(re *regexp.Regexp, replacement string) RedactionRule {
+	return RedactionRuleFunc(func(_, value string) (string, int) {
+		n := len(re.FindAllStringIndex(value, -1))
+		if n == 0 {
+			return value, 0
+		}
+		return re.ReplaceAllString(value, replacement), n
+	})
+}
+
+// RegexHashRedactionRule returns a RedactionRule that replaces the matches of
+// re with their HMAC-SHA256 keyed with salt, in hex, so equal values can
+// still be correlated.
+func RegexHashRedactionRule(re *regexp.Regexp, salt []byte) RedactionRule {
+	return RedactionRuleFunc(func(_, value string) (string, int) {
+		n := 0
+		value = re.ReplaceAllStringFunc(value, func(match string) string {
+			n++
+			return saltedHash(salt, match)
+		})
+		return value, n
+	})
+}
+
+// KeyRedactionRule returns a RedactionRule that replaces the whole value of
+// the attributes and map entries with one of the keys, compared case
+// insensitively, with RedactionMask.
+func KeyRedactionRule(keys ...string) RedactionRule {
+	set := keySet(keys)
+	return RedactionRuleFunc(func(key, value string) (string, int) {
+		if _, ok := set[strings.ToLower(key)]; !ok {
+			return value, 0
+		}
+		return RedactionMask, 1
+	})
+}
+
+// KeyHashRedactionRule returns a RedactionRule that replaces the whole value
+// of the attributes and map entries with one of the keys, compared case
+// insensitively, with its HMAC-SHA256 keyed with salt, in hex.
+func KeyHashRedactionRule(salt []byte, keys ...string) RedactionRule {
+	set := keySet(keys)
+	return RedactionRuleFunc(func(key, value string) (string, int) {
+		if _, ok := set[strings.ToLower(key)]; !ok {
+			return value, 0
+		}
+		return saltedHash(salt, value), 1
+	})
+}
+
+var (
+	emailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	cardNumberPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	bearerPattern     = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+	jwtPattern        = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+)
+
+// SecretKeys are attribute and map keys whose values are commonly secret.
+var SecretKeys = []string{
+	"password", "passwd", "pwd", "secret", "token", "access_token", "refresh_token",
+	"api_key", "apikey", "authorization", "cookie", "set-cookie", "private_key",
+}
+
+// EmailRedactionRule returns a RedactionRule that masks email addresses.
+func EmailRedactionRule() RedactionRule {
+	return RegexRedactionRule(emailPattern, RedactionMask)
+}
+
+// CardNumberRedactionRule returns a RedactionRule that masks payment card
+// numbers: digits optionally separated by spaces or dashes with the issuer
+// prefix and length of a Visa, Mastercard, American Express, Discover, JCB,
+// Diners Club or UnionPay card that pass the Luhn check. Other numbers, such as
+// order or account numbers, are kept even if they pass the Luhn check.
+func CardNumberRedactionRule() RedactionRule {
+	return RedactionRuleFunc(func(_, value string) (string, int) {
+		n := 0
+		value = cardNumberPattern.ReplaceAllStringFunc(value, func(match string) string {
+			if !cardNumberValid(match) {
+				return match
+			}
+			n++
+			return RedactionMask
+		})
+		return value, n
+	})
+}
+
+// TokenRedactionRule returns a RedactionRule that masks bearer and basic
+// authorization credentials and JSON web tokens.
+func TokenRedactionRule() RedactionRule {
+	bearer := RegexRedactionRule(bearerPattern, "$1 "+RedactionMask)
+	jwt := RegexRedactionRule(jwtPattern, RedactionMask)
+	return RedactionRuleFunc(func(key, value string) (string, int) {
+		value, n := bearer.Redact(key, value)
+		value, m := jwt.Redact(key, value)
+		return value, n + m
+	})
+}
+
+// DefaultRedactionRules returns the built-in rules for common PII and
+// secrets: the values of the SecretKeys, email addresses, payment card
+// numbers and tokens.
+func DefaultRedactionRules() []RedactionRule {
+	return []RedactionRule{
+		KeyRedactionRule(SecretKeys...),
+		EmailRedactionRule(),
+		CardNumberRedactionRule(),
+		TokenRedactionRule(),
+	}
+}
+
+// RedactionProcessorOption configures a RedactionProcessor.
+type RedactionProcessorOption func(cfg *redactionConfig)
+
+type redactionConfig struct {
+	rules   []RedactionRule
+	allowed map[string]struct{}
+}
+
+// WithRedactionRules sets the rules applied, in order. The default is
+// DefaultRedactionRules.
+func WithRedactionRules(rules ...RedactionRule) RedactionProcessorOption {
+	return func(cfg *redactionConfig) {
+		cfg.rules = rules
+	}
+}
+
+// WithRedactionAllowedKeys exempts the attributes and map entries with one of
+// the keys, compared case insensitively, from all rules.
+func WithRedactionAllowedKeys(keys ...string) RedactionProcessorOption {
+	return func(cfg *redactionConfig) {
+		for k := range keySet(keys) {
+			cfg.allowed[k] = struct{}{}
+		}
+	}
+}
+
+// RedactionProcessor is a LogRecordProcessor that redacts sensitive data
+// from the body, attributes and structured attributes of log records before
+// passing them on to another LogRecordProcessor.
+//
+// The rules apply to string values, the elements of slices and the entries of
+// maps, and to numbers and booleans in their string form. Bytes values, of
+// bodies, structured attributes or nested in slices and maps, are passed on
+// unchanged as they are not necessarily text; drop or convert them before this
+// processor if they can hold sensitive data.
+//
+// Log records with redactions get the RedactionCountKey attribute. As log
+// records are modified in place, processors registered after this one also
+// see the redacted log records.
+type RedactionProcessor struct {
+	next LogRecordProcessor
+	cfg  redactionConfig
+}
+
+var _ ContextLogRecordProcessor = (*RedactionProcessor)(nil)
+
+// NewRedactionProcessor returns a RedactionProcessor that redacts log records
+// with the rules of the options, DefaultRedactionRules by default, and passes
+// them on to next.
+func NewRedactionProcessor(next LogRecordProcessor, opts ...RedactionProcessorOption) *RedactionProcessor {
+	cfg := redactionConfig{allowed: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.rules == nil {
+		cfg.rules = DefaultRedactionRules()
+	}
+	return &RedactionProcessor{next: next, cfg: cfg}
+}
+
+// OnEmit redacts rol and passes it on.
+func (p *RedactionProcessor) OnEmit(rol ReadableLogRecord) {
+	p.OnEmitContext(context.Background(), rol)
+}
+
+// OnEmitContext redacts rol and passes it on within ctx.
+func (p *RedactionProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	rw, ok := rol.(ReadWriteLogRecord)
+	if !ok {
+		rw = copyLogRecord(rol)
+	}
+
+	count := 0
+	if body, n := p.redactValue("", rw.BodyValue()); n > 0 {
+		rw.SetBodyValue(body)
+		count += n
+	}
+	if attrs := rw.Attributes(); attrs != nil {
+		redacted := make([]attribute.KeyValue, len(*attrs))
+		n := 0
+		for i, a := range *attrs {
+			redacted[i] = p.redactAttribute(a, &n)
+		}
+		if n > 0 {
+			rw.SetAttributes(redacted...)
+			count += n
+		}
+	}
+	if kvs := rw.StructuredAttributes(); len(kvs) > 0 {
+		redacted := make([]logs.KeyValue, len(kvs))
+		n := 0
+		for i, kv := range kvs {
+			v, m := p.redactValue(kv.Key, kv.Value)
+			redacted[i] = logs.KeyValue{Key: kv.Key, Value: v}
+			n += m
+		}
+		if n > 0 {
+			rw.SetStructuredAttributes(redacted...)
+			count += n
+		}
+	}
+
+	if count > 0 {
+		rw.SetAttribute(RedactionCountKey.Int(count))
+	}
+	onEmit(ctx, p.next, rw)
+}
+
+// Shutdown shuts the wrapped processor down.
+func (p *RedactionProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the wrapped processor.
+func (p *RedactionProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// redact applies the rules to value.
+func (p *RedactionProcessor) redact(key, value string) (string, int) {
+	if _, ok := p.cfg.allowed[strings.ToLower(key)]; ok {
+		return value, 0
+	}
+	count := 0
+	for _, r := range p.cfg.rules {
+		var n int
+		value, n = r.Redact(key, value)
+		count += n
+	}
+	return value, count
+}
+
+// redactValue applies the rules to v and the values it holds.
+func (p *RedactionProcessor) redactValue(key string, v logs.Value) (logs.Value, int) {
+	switch v.Kind() {
+	case logs.KindString:
+		s, n := p.redact(key, v.AsString())
+		if n == 0 {
+			return v, 0
+		}
+		return logs.StringValue(s), n
+	case logs.KindInt64, logs.KindFloat64, logs.KindBool:
+		// Numbers and booleans become strings if anything is redacted.
+		if s, n := p.redact(key, v.String()); n > 0 {
+			return logs.StringValue(s), n
+		}
+		return v, 0
+	case logs.KindSlice:
+		elems := v.AsSlice()
+		var redacted []logs.Value
+		count := 0
+		for i, e := range elems {
+			r, n := p.redactValue(key, e)
+			if n > 0 && redacted == nil {
+				redacted = append(make([]logs.Value, 0, len(elems)), elems[:i]...)
+			}
+			if redacted != nil {
+				redacted = append(redacted, r)
+			}
+			count += n
+		}
+		if count == 0 {
+			return v, 0
+		}
+		return logs.SliceValue(redacted...), count
+	case logs.KindMap:
+		kvs := v.AsMap()
+		redacted := make([]logs.KeyValue, len(kvs))
+		count := 0
+		for i, kv := range kvs {
+			r, n := p.redactValue(kv.Key, kv.Value)
+			redacted[i] = logs.KeyValue{Key: kv.Key, Value: r}
+			count += n
+		}
+		if count == 0 {
+			return v, 0
+		}
+		return logs.MapValue(redacted...), count
+	default:
+		// Bytes are not redacted, see RedactionProcessor.
+		return v, 0
+	}
+}
+
+// redactAttribute applies the rules to the value of a, adding the number of
+// redactions to count.
+func (p *RedactionProcessor) redactAttribute(a attribute.KeyValue, count *int) attribute.KeyValue {
+	key := string(a.Key)
+	switch a.Value.Type() {
+	case attribute.STRING:
+		if s, n := p.redact(key, a.Value.AsString()); n > 0 {
+			*count += n
+			return a.Key.String(s)
+		}
+	case attribute.STRINGSLICE:
+		elems := a.Value.AsStringSlice()
+		redacted := make([]string, len(elems))
+		total := 0
+		for i, e := range elems {
+			var n int
+			redacted[i], n = p.redact(key, e)
+			total += n
+		}
+		if total > 0 {
+			*count += total
+			return a.Key.StringSlice(redacted)
+		}
+	case attribute.BOOL, attribute.INT64, attribute.FLOAT64:
+		if s, n := p.redact(key, a.Value.Emit()); n > 0 {
+			*count += n
+			return a.Key.String(s)
+		}
+	}
+	return a
+}
+
+func keySet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	return set
+}
+
+func saltedHash(salt []byte, value string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// cardNetwork is the issuer prefix range and the lengths of the card numbers
+// of a payment network.
+type cardNetwork struct {
+	prefixLen          int
+	prefixLo, prefixHi int
+	minLen, maxLen     int
+}
+
+// cardNetworks are the issuer identification number ranges of the major
+// payment networks.
+var cardNetworks = []cardNetwork{
+	{prefixLen: 1, prefixLo: 4, prefixHi: 4, minLen: 13, maxLen: 13},       // Visa
+	{prefixLen: 1, prefixLo: 4, prefixHi: 4, minLen: 16, maxLen: 16},       // Visa
+	{prefixLen: 1, prefixLo: 4, prefixHi: 4, minLen: 19, maxLen: 19},       // Visa
+	{prefixLen: 2, prefixLo: 51, prefixHi: 55, minLen: 16, maxLen: 16},     // Mastercard
+	{prefixLen: 4, prefixLo: 2221, prefixHi: 2720, minLen: 16, maxLen: 16}, // Mastercard
+	{prefixLen: 2, prefixLo: 34, prefixHi: 34, minLen: 15, maxLen: 15},     // American Express
+	{prefixLen: 2, prefixLo: 37, prefixHi: 37, minLen: 15, maxLen: 15},     // American Express
+	{prefixLen: 4, prefixLo: 6011, prefixHi: 6011, minLen: 16, maxLen: 19}, // Discover
+	{prefixLen: 3, prefixLo: 644, prefixHi: 649, minLen: 16, maxLen: 19},   // Discover
+	{prefixLen: 2, prefixLo: 65, prefixHi: 65, minLen: 16, maxLen: 19},     // Discover
+	{prefixLen: 4, prefixLo: 3528, prefixHi: 3589, minLen: 16, maxLen: 19}, // JCB
+	{prefixLen: 3, prefixLo: 300, prefixHi: 305, minLen: 14, maxLen: 19},   // Diners Club
+	{prefixLen: 2, prefixLo: 36, prefixHi: 36, minLen: 14, maxLen: 19},     // Diners Club
+	{prefixLen: 2, prefixLo: 38, prefixHi: 39, minLen: 16, maxLen: 19},     // Diners Club
+	{prefixLen: 2, prefixLo: 62, prefixHi: 62, minLen: 16, maxLen: 19},     // UnionPay
+}
+
+// cardNumberValid reports whether the digits of s have the issuer prefix and
+// length of a payment network and pass the Luhn check.
+func cardNumberValid(s string) bool {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= '0' && c <= '9' {
+			digits = append(digits, c)
+		}
+	}
+	for _, nw := range cardNetworks {
+		if nw.matches(digits) {
+			return luhnValid(s)
+		}
+	}
+	return false
+}
+
+func (nw cardNetwork) matches(digits []byte) bool {
+	if len(digits) < nw.minLen || len(digits) > nw.maxLen {
+		return false
+	}
+	prefix := 0
+	for _, c := range digits[:nw.prefixLen] {
+		prefix = prefix*10 + int(c-'0')
+	}
+	return prefix >= nw.prefixLo && prefix <= nw.prefixHi
+}
+
+// luhnValid reports whether the digits of s pass the Luhn check.
+func luhnValid(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,153 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"regexp"
+	"testing"
+)
+
+func TestRedactionProcessor(t *testing.T) {
+	next := &testProcessor{}
+	logger := NewLoggerProvider(WithLogRecordProcessor(NewRedactionProcessor(next))).Logger("test")
+
+	attrs := []attribute.KeyValue{
+		attribute.String("user", "jane@example.com"),
+		attribute.String("Password", "hunter2"),
+		attribute.Int("card", 4111111111111111),
+		attribute.Int("order", 1234567890123),
+		attribute.StringSlice("cc", []string{"a@example.org", "none"}),
+	}
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		BodyValue:  logs.StringValue("paid with 4111 1111 1111 1111, Authorization: Bearer abc.def"),
+		Attributes: &attrs,
+		StructuredAttributes: []logs.KeyValue{
+			logs.Map("request", logs.String("token", "s3cr3t"), logs.String("path", "/users")),
+		},
+	}))
+
+	require.Len(t, next.records, 1)
+	r := next.records[0]
+	assert.Equal(t, "paid with [REDACTED], Authorization: Bearer [REDACTED]", r.BodyValue().AsString())
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("user", "[REDACTED]"),
+		attribute.String("Password", "[REDACTED]"),
+		attribute.String("card", "[REDACTED]"),
+		attribute.Int("order", 1234567890123),
+		attribute.StringSlice("cc", []string{"[REDACTED]", "none"}),
+		RedactionCountKey.Int(7),
+	}, *r.Attributes())
+	assert.Equal(t, []logs.KeyValue{
+		logs.Map("request", logs.String("token", "[REDACTED]"), logs.String("path", "/users")),
+	}, r.StructuredAttributes())
+
+	// The caller's attributes are not modified.
+	assert.Equal(t, attribute.String("Password", "hunter2"), attrs[1])
+}
+
+func TestRedactionProcessorUnchanged(t *testing.T) {
+	next := &testProcessor{}
+	logger := NewLoggerProvider(WithLogRecordProcessor(NewRedactionProcessor(next))).Logger("test")
+	attrs := []attribute.KeyValue{attribute.String("path", "/users")}
+
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		BodyValue:  logs.MapValue(logs.String("message", "nothing to hide")),
+		Attributes: &attrs,
+	}))
+
+	require.Len(t, next.records, 1)
+	assert.Equal(t, logs.MapValue(logs.String("message", "nothing to hide")), next.records[0].BodyValue())
+	assert.Equal(t, attrs, *next.records[0].Attributes())
+}
+
+func TestRedactionProcessorRules(t *testing.T) {
+	salt := []byte("salt")
+	next := &testProcessor{}
+	processor := NewRedactionProcessor(next,
+		WithRedactionRules(
+			KeyHashRedactionRule(salt, "user.id"),
+			RegexHashRedactionRule(regexp.MustCompile(`\d{3}-\d{4}`), salt),
+			KeyRedactionRule("ssn"),
+		),
+		WithRedactionAllowedKeys("ticket"),
+	)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	attrs := []attribute.KeyValue{
+		attribute.String("user.id", "42"),
+		attribute.String("ticket", "555-1234"),
+		attribute.String("ssn", "123"),
+	}
+
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		BodyValue:  logs.StringValue("call 555-1234"),
+		Attributes: &attrs,
+	}))
+
+	require.Len(t, next.records, 1)
+	assert.Equal(t, "call "+saltedHash(salt, "555-1234"), next.records[0].BodyValue().AsString())
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("user.id", saltedHash(salt, "42")),
+		attribute.String("ticket", "555-1234"),
+		attribute.String("ssn", RedactionMask),
+		RedactionCountKey.Int(3),
+	}, *next.records[0].Attributes())
+	assert.NotEqual(t, saltedHash(salt, "42"), saltedHash([]byte("pepper"), "42"))
+}
+
+func TestDefaultRedactionRules(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"", "mail john.doe+x@mail.example.co.uk now", "mail [REDACTED] now"},
+		{"", "card 4111-1111-1111-1111", "card [REDACTED]"},
+		{"", "order 4111-1111-1111-1112", "order 4111-1111-1111-1112"},
+		{"", "amex 3782 822463 10005", "amex [REDACTED]"},
+		{"", "mc 2221000000000009", "mc [REDACTED]"},
+		{"", "discover 6011111111111117", "discover [REDACTED]"},
+		{"", "order 1234567812345670", "order 1234567812345670"},
+		{"", "visa length 411111111111111", "visa length 411111111111111"},
+		{"", "jwt eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig", "jwt [REDACTED]"},
+		{"", "basic dXNlcjpwYXNz", "basic [REDACTED]"},
+		{"api_key", "abc", "[REDACTED]"},
+		{"message", "abc", "abc"},
+	}
+	for _, tt := range tests {
+		value := tt.value
+		for _, r := range DefaultRedactionRules() {
+			value, _ = r.Redact(tt.key, value)
+		}
+		assert.Equal(t, tt.want, value, tt.value)
+	}
+}
+
+func TestRedactionProcessorBytes(t *testing.T) {
+	next := &testProcessor{}
+	logger := NewLoggerProvider(WithLogRecordProcessor(NewRedactionProcessor(next))).Logger("test")
+
+	// Bytes are passed on unchanged.
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		BodyValue: logs.BytesValue([]byte("john@example.com")),
+	}))
+
+	require.Len(t, next.records, 1)
+	assert.Equal(t, []byte("john@example.com"), next.records[0].BodyValue().AsBytes())
+	assert.Nil(t, next.records[0].Attributes())
+}
@@ -0,0 +1,206 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"errors"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	"reflect"
+)
+
+// RoutePredicate reports whether a log record emitted within ctx takes a
+// route.
+type RoutePredicate func(ctx context.Context, rol ReadableLogRecord) bool
+
+// Route sends the log records matching Predicate to Processor.
+type Route struct {
+	Predicate RoutePredicate
+	Processor LogRecordProcessor
+}
+
+// MatchSeverity returns a RoutePredicate matching the log records with a
+// severity from min to max, inclusive.
+func MatchSeverity(min, max logs.SeverityNumber) RoutePredicate {
+	return func(_ context.Context, rol ReadableLogRecord) bool {
+		sn := rol.SeverityNumber()
+		return sn != nil && *sn >= min && *sn <= max
+	}
+}
+
+// MatchScope returns a RoutePredicate matching the log records of the
+// instrumentation scopes whose name matches pattern, see WithScopeMinSeverity.
+func MatchScope(pattern string) RoutePredicate {
+	return func(_ context.Context, rol ReadableLogRecord) bool {
+		var name string
+		if is := rol.InstrumentationScope(); is != nil {
+			name = is.Name
+		}
+		return globMatch(pattern, name)
+	}
+}
+
+// MatchResourceAttribute returns a RoutePredicate matching the log records
+// whose resource has the attribute kv.
+func MatchResourceAttribute(kv attribute.KeyValue) RoutePredicate {
+	return func(_ context.Context, rol ReadableLogRecord) bool {
+		if rol.Resource() == nil {
+			return false
+		}
+		v, ok := rol.Resource().Set().Value(kv.Key)
+		return ok && v == kv.Value
+	}
+}
+
+// MatchAttribute returns a RoutePredicate matching the log records that have
+// the attribute kv, or a structured attribute with the key and value of kv.
+func MatchAttribute(kv attribute.KeyValue) RoutePredicate {
+	key, value := string(kv.Key), logs.ValueOf(kv.Value.AsInterface())
+	return func(_ context.Context, rol ReadableLogRecord) bool {
+		if attrs := rol.Attributes(); attrs != nil {
+			for _, a := range *attrs {
+				if a.Key == kv.Key && a.Value == kv.Value {
+					return true
+				}
+			}
+		}
+		for _, a := range rol.StructuredAttributes() {
+			if a.Key == key && a.Value.Equal(value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchAll returns a RoutePredicate matching the log records that all
+// predicates match.
+func MatchAll(predicates ...RoutePredicate) RoutePredicate {
+	return func(ctx context.Context, rol ReadableLogRecord) bool {
+		for _, p := range predicates {
+			if !p(ctx, rol) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchAny returns a RoutePredicate matching the log records that any of
+// predicates matches.
+func MatchAny(predicates ...RoutePredicate) RoutePredicate {
+	return func(ctx context.Context, rol ReadableLogRecord) bool {
+		for _, p := range predicates {
+			if p(ctx, rol) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RoutingProcessor is a LogRecordProcessor that passes each log record on to
+// the LogRecordProcessor of the first Route whose predicate matches it, or to
+// a default LogRecordProcessor if none does.
+//
+// Shutdown and ForceFlush are passed on to the processors of all routes, once
+// for each processor.
+type RoutingProcessor struct {
+	routes       []Route
+	defaultRoute LogRecordProcessor
+	// processors holds every processor once, in the order of the routes.
+	processors []LogRecordProcessor
+}
+
+var _ ContextLogRecordProcessor = (*RoutingProcessor)(nil)
+var _ FilterProcessor = (*RoutingProcessor)(nil)
+
+// NewRoutingProcessor returns a RoutingProcessor that passes each log record
+// on to the processor of the first of routes whose predicate matches it, or to
+// defaultRoute if none does. defaultRoute can be nil to drop those log
+// records.
+func NewRoutingProcessor(defaultRoute LogRecordProcessor, routes ...Route) *RoutingProcessor {
+	p := &RoutingProcessor{routes: routes, defaultRoute: defaultRoute}
+	for _, r := range routes {
+		p.addProcessor(r.Processor)
+	}
+	if defaultRoute != nil {
+		p.addProcessor(defaultRoute)
+	}
+	return p
+}
+
+// addProcessor adds lp to the processors unless it is there already.
+func (p *RoutingProcessor) addProcessor(lp LogRecordProcessor) {
+	// Processors of types that are not comparable cannot be compared.
+	if reflect.TypeOf(lp).Comparable() {
+		for _, other := range p.processors {
+			if other == lp {
+				return
+			}
+		}
+	}
+	p.processors = append(p.processors, lp)
+}
+
+// OnEmit passes rol on to the processor of its route.
+func (p *RoutingProcessor) OnEmit(rol ReadableLogRecord) {
+	p.OnEmitContext(context.Background(), rol)
+}
+
+// OnEmitContext passes rol on within ctx to the processor of its route.
+func (p *RoutingProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	for _, r := range p.routes {
+		if r.Predicate(ctx, rol) {
+			onEmit(ctx, r.Processor, rol)
+			return
+		}
+	}
+	if p.defaultRoute != nil {
+		onEmit(ctx, p.defaultRoute, rol)
+	}
+}
+
+// Enabled reports whether any of the processors would process a log record
+// with param.
+func (p *RoutingProcessor) Enabled(ctx context.Context, param logs.EnabledParameters) bool {
+	for _, lp := range p.processors {
+		if enabled(ctx, lp, param) {
+			return true
+		}
+	}
+	return false
+}
+
+// Shutdown shuts the processors of all routes down.
+func (p *RoutingProcessor) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, lp := range p.processors {
+		errs = append(errs, lp.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// ForceFlush flushes the processors of all routes.
+func (p *RoutingProcessor) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, lp := range p.processors {
+		errs = append(errs, lp.ForceFlush(ctx))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"errors"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"testing"
+)
+
+type shutdownCountingProcessor struct {
+	testProcessor
+	shutdowns, flushes int
+	err                error
+}
+
+func (p *shutdownCountingProcessor) Shutdown(context.Context) error {
+	p.shutdowns++
+	return p.err
+}
+
+func (p *shutdownCountingProcessor) ForceFlush(context.Context) error {
+	p.flushes++
+	return p.err
+}
+
+func TestRoutingProcessor(t *testing.T) {
+	errorsRoute, audit, other := &testProcessor{}, &testProcessor{}, &testProcessor{}
+	processor := NewRoutingProcessor(other,
+		Route{Predicate: MatchSeverity(logs.ERROR, logs.FATAL4), Processor: errorsRoute},
+		Route{Predicate: MatchScope("audit/*"), Processor: audit},
+	)
+	provider := NewLoggerProvider(WithLogRecordProcessor(processor))
+
+	emitSeverity(provider.Logger("app"), logs.ERROR)
+	emitSeverity(provider.Logger("audit/login"), logs.INFO)
+	emitSeverity(provider.Logger("audit/login"), logs.FATAL)
+	emitSeverity(provider.Logger("app"), logs.INFO)
+
+	assert.Len(t, errorsRoute.records, 2)
+	require.Len(t, audit.records, 1)
+	assert.Equal(t, "audit/login", audit.records[0].InstrumentationScope().Name)
+	require.Len(t, other.records, 1)
+	assert.Equal(t, logs.INFO, *other.records[0].SeverityNumber())
+}
+
+func TestRoutingProcessorAttributes(t *testing.T) {
+	tenant, other := &testProcessor{}, &testProcessor{}
+	processor := NewRoutingProcessor(nil,
+		Route{
+			Predicate: MatchAll(
+				MatchResourceAttribute(attribute.String("deployment.environment", "prod")),
+				MatchAny(MatchAttribute(attribute.String("tenant", "a")), MatchAttribute(attribute.Int("tier", 1))),
+			),
+			Processor: tenant,
+		},
+		Route{Predicate: MatchAttribute(attribute.String("tenant", "b")), Processor: other},
+	)
+	res := resource.NewSchemaless(attribute.String("deployment.environment", "prod"))
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor), WithResource(res)).Logger("test")
+
+	for _, attrs := range [][]attribute.KeyValue{
+		{attribute.String("tenant", "a")},
+		{attribute.Int("tier", 1)},
+		{attribute.String("tenant", "b")},
+		{attribute.String("tenant", "c")},
+	} {
+		attrs := attrs
+		logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{Attributes: &attrs}))
+	}
+
+	assert.Len(t, tenant.records, 2)
+	assert.Len(t, other.records, 1)
+
+	// Structured attributes match too.
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		StructuredAttributes: []logs.KeyValue{logs.String("tenant", "a")},
+	}))
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		StructuredAttributes: []logs.KeyValue{logs.Int("tier", 1)},
+	}))
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{
+		StructuredAttributes: []logs.KeyValue{logs.Map("tenant", logs.String("id", "b"))},
+	}))
+	assert.Len(t, tenant.records, 4)
+	assert.Len(t, other.records, 1)
+}
+
+func TestRoutingProcessorShutdown(t *testing.T) {
+	errFailed := errors.New("failed")
+	first, second := &shutdownCountingProcessor{err: errFailed}, &shutdownCountingProcessor{}
+	processor := NewRoutingProcessor(second,
+		Route{Predicate: MatchSeverity(logs.ERROR, logs.FATAL4), Processor: first},
+		Route{Predicate: MatchScope("audit"), Processor: second},
+		Route{Predicate: MatchScope("other"), Processor: funcProcessor{onEmit: func(ReadableLogRecord) {}}},
+	)
+
+	assert.ErrorIs(t, processor.ForceFlush(context.Background()), errFailed)
+	assert.ErrorIs(t, processor.Shutdown(context.Background()), errFailed)
+	assert.Equal(t, 1, first.flushes)
+	assert.Equal(t, 1, first.shutdowns)
+	assert.Equal(t, 1, second.flushes)
+	assert.Equal(t, 1, second.shutdowns)
+}
+
+func TestRoutingProcessorEnabled(t *testing.T) {
+	processor := NewRoutingProcessor(nil,
+		Route{Predicate: MatchScope("audit"), Processor: &severityFilterProcessor{min: logs.WARN}},
+	)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	assert.False(t, logger.Enabled(context.Background(), logs.EnabledParameters{Severity: logs.INFO}))
+	assert.True(t, logger.Enabled(context.Background(), logs.EnabledParameters{Severity: logs.ERROR}))
+}
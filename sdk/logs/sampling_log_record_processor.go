@@ -0,0 +1,166 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"math/rand/v2"
+)
+
+// SamplingRatioKey is the attribute key of the ratio a log record was
+// sampled with. Backends can count each kept log record as 1/ratio records.
+const SamplingRatioKey = attribute.Key("log.record.sampling.ratio")
+
+// TraceSamplingProcessorOption configures a TraceSamplingProcessor.
+type TraceSamplingProcessorOption func(cfg *traceSamplingConfig)
+
+type traceSamplingConfig struct {
+	unsampledTraces ratioSampler
+}
+
+// WithUnsampledTraceRatio sets the ratio of the traces that were not sampled
+// whose log records are kept anyway. All log records of a trace get the same
+// decision. The default is 0, log records of traces that were not sampled are
+// dropped.
+func WithUnsampledTraceRatio(ratio float64) TraceSamplingProcessorOption {
+	return func(cfg *traceSamplingConfig) {
+		cfg.unsampledTraces = newRatioSampler(ratio)
+	}
+}
+
+// TraceSamplingProcessor is a LogRecordProcessor that passes a sample of the
+// log records on to another LogRecordProcessor.
+//
+// Log records of sampled traces are always kept, so the logs of the traces
+// that are recorded stay complete. Of the log records without a trace, a
+// ratio is kept at random. Log records of traces that were not sampled are
+// dropped, see WithUnsampledTraceRatio.
+//
+// Decisions based on a trace use the algorithm of the trace SDK's
+// TraceIDRatioBased sampler. Kept log records get the SamplingRatioKey
+// attribute.
+type TraceSamplingProcessor struct {
+	next            LogRecordProcessor
+	noTrace         ratioSampler
+	unsampledTraces ratioSampler
+}
+
+var _ ContextLogRecordProcessor = (*TraceSamplingProcessor)(nil)
+var _ FilterProcessor = (*TraceSamplingProcessor)(nil)
+
+// NewTraceSamplingProcessor returns a TraceSamplingProcessor that passes the
+// log records of sampled traces and the given ratio of the log records
+// without a trace on to next.
+func NewTraceSamplingProcessor(next LogRecordProcessor, ratio float64, opts ...TraceSamplingProcessorOption) *TraceSamplingProcessor {
+	cfg := traceSamplingConfig{unsampledTraces: newRatioSampler(0)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &TraceSamplingProcessor{
+		next:            next,
+		noTrace:         newRatioSampler(ratio),
+		unsampledTraces: cfg.unsampledTraces,
+	}
+}
+
+// OnEmit passes rol on if it is sampled.
+func (p *TraceSamplingProcessor) OnEmit(rol ReadableLogRecord) {
+	p.OnEmitContext(context.Background(), rol)
+}
+
+// OnEmitContext passes rol on within ctx if it is sampled.
+func (p *TraceSamplingProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	var ratio float64
+	switch {
+	case rol.TraceId() == nil || !rol.TraceId().IsValid():
+		if !p.noTrace.sample(rand.Uint64()) {
+			return
+		}
+		ratio = p.noTrace.ratio
+	case rol.TraceFlags() != nil && rol.TraceFlags().IsSampled():
+		ratio = 1
+	default:
+		if !p.unsampledTraces.sampleTrace(*rol.TraceId()) {
+			return
+		}
+		ratio = p.unsampledTraces.ratio
+	}
+
+	if rw, ok := rol.(ReadWriteLogRecord); ok {
+		rw.SetAttribute(SamplingRatioKey.Float64(ratio))
+	}
+	onEmit(ctx, p.next, rol)
+}
+
+// Enabled reports whether a log record emitted within ctx could be kept.
+func (p *TraceSamplingProcessor) Enabled(ctx context.Context, param logs.EnabledParameters) bool {
+	sc := trace.SpanContextFromContext(ctx)
+	switch {
+	case !sc.IsValid():
+		if p.noTrace.ratio <= 0 {
+			return false
+		}
+	case sc.IsSampled():
+	default:
+		if !p.unsampledTraces.sampleTrace(sc.TraceID()) {
+			return false
+		}
+	}
+	return enabled(ctx, p.next, param)
+}
+
+// Shutdown shuts the wrapped processor down.
+func (p *TraceSamplingProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the wrapped processor.
+func (p *TraceSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// ratioSampler keeps a ratio of values, see
+// go.opentelemetry.io/otel/sdk/trace.TraceIDRatioBased.
+type ratioSampler struct {
+	ratio float64
+	// bound is the upper bound of the values that are sampled.
+	bound uint64
+}
+
+func newRatioSampler(ratio float64) ratioSampler {
+	if ratio >= 1 {
+		return ratioSampler{ratio: 1, bound: 1 << 63}
+	}
+	if ratio <= 0 {
+		return ratioSampler{}
+	}
+	return ratioSampler{ratio: ratio, bound: uint64(ratio * (1 << 63))}
+}
+
+// sample reports whether the random value x is sampled.
+func (s ratioSampler) sample(x uint64) bool {
+	return x>>1 < s.bound
+}
+
+// sampleTrace reports whether the trace with the given id is sampled.
+func (s ratioSampler) sampleTrace(id trace.TraceID) bool {
+	return s.sample(binary.BigEndian.Uint64(id[8:16]))
+}
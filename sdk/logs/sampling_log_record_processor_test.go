@@ -0,0 +1,101 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func traceContext(id uint64, flags trace.TraceFlags) context.Context {
+	var traceID trace.TraceID
+	traceID[0] = 1
+	binary.BigEndian.PutUint64(traceID[8:], id)
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: flags,
+	}))
+}
+
+func TestTraceSamplingProcessor(t *testing.T) {
+	next := &testProcessor{}
+	logger := NewLoggerProvider(WithLogRecordProcessor(NewTraceSamplingProcessor(next, 0))).Logger("test")
+
+	logger.EmitContext(traceContext(1, trace.FlagsSampled), logs.NewLogRecord(logs.LogRecordConfig{}))
+	logger.EmitContext(traceContext(2, 0), logs.NewLogRecord(logs.LogRecordConfig{}))
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{}))
+
+	require.Len(t, next.records, 1)
+	assert.Equal(t, []attribute.KeyValue{SamplingRatioKey.Float64(1)}, *next.records[0].Attributes())
+}
+
+func TestTraceSamplingProcessorRatio(t *testing.T) {
+	next := &testProcessor{}
+	logger := NewLoggerProvider(WithLogRecordProcessor(NewTraceSamplingProcessor(next, 0.25))).Logger("test")
+
+	const n = 4000
+	for i := 0; i < n; i++ {
+		logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{}))
+	}
+	assert.InDelta(t, n/4, len(next.records), n/20)
+	assert.Equal(t, []attribute.KeyValue{SamplingRatioKey.Float64(0.25)}, *next.records[0].Attributes())
+}
+
+func TestTraceSamplingProcessorUnsampledTraces(t *testing.T) {
+	const ratio = 0.5
+	next := &testProcessor{}
+	processor := NewTraceSamplingProcessor(next, 0, WithUnsampledTraceRatio(ratio))
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	sampler := sdktrace.TraceIDRatioBased(ratio)
+
+	kept := 0
+	for i := uint64(0); i < 64; i++ {
+		ctx := traceContext(i<<58, 0)
+		sc := trace.SpanContextFromContext(ctx)
+		// The decision matches the trace SDK and is the same for every log
+		// record of a trace.
+		want := sampler.ShouldSample(sdktrace.SamplingParameters{TraceID: sc.TraceID()}).Decision == sdktrace.RecordAndSample
+		assert.Equal(t, want, processor.Enabled(ctx, logs.EnabledParameters{}))
+		before := len(next.records)
+		logger.EmitContext(ctx, logs.NewLogRecord(logs.LogRecordConfig{}))
+		logger.EmitContext(ctx, logs.NewLogRecord(logs.LogRecordConfig{}))
+		if want {
+			kept++
+			assert.Equal(t, before+2, len(next.records))
+		} else {
+			assert.Equal(t, before, len(next.records))
+		}
+	}
+	assert.Equal(t, 32, kept)
+	assert.Equal(t, []attribute.KeyValue{SamplingRatioKey.Float64(ratio)}, *next.records[0].Attributes())
+}
+
+func TestTraceSamplingProcessorEnabled(t *testing.T) {
+	processor := NewTraceSamplingProcessor(&testProcessor{}, 0)
+
+	assert.True(t, processor.Enabled(traceContext(1, trace.FlagsSampled), logs.EnabledParameters{}))
+	assert.False(t, processor.Enabled(traceContext(1, 0), logs.EnabledParameters{}))
+	assert.False(t, processor.Enabled(context.Background(), logs.EnabledParameters{}))
+}
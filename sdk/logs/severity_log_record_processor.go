@@ -0,0 +1,245 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// MinSeverityProcessorOption configures a MinSeverityProcessor.
+type MinSeverityProcessorOption func(cfg *minSeverityConfig)
+
+// WithScopeMinSeverity sets the minimum severity of the log records of the
+// instrumentation scopes whose name matches pattern. In pattern, '*' matches
+// any sequence of characters, including '/', and '?' matches any single
+// character.
+//
+// When several patterns match a scope name, the most specific one, with the
+// most characters that are not wildcards, is used.
+func WithScopeMinSeverity(pattern string, min logs.SeverityNumber) MinSeverityProcessorOption {
+	return func(cfg *minSeverityConfig) {
+		cfg.setScope(pattern, min)
+	}
+}
+
+// MinSeverityProcessor is a LogRecordProcessor that passes the log records
+// with at least a minimum severity on to another LogRecordProcessor and drops
+// the others.
+//
+// Log records without a severity, such as events, are always passed on.
+type MinSeverityProcessor struct {
+	next LogRecordProcessor
+	cfg  atomic.Pointer[minSeverityConfig]
+	// mu serializes the setters.
+	mu sync.Mutex
+}
+
+var _ ContextLogRecordProcessor = (*MinSeverityProcessor)(nil)
+var _ FilterProcessor = (*MinSeverityProcessor)(nil)
+
+// NewMinSeverityProcessor returns a MinSeverityProcessor that passes the log
+// records with at least severity min, or the minimum of their instrumentation
+// scope, on to next.
+func NewMinSeverityProcessor(next LogRecordProcessor, min logs.SeverityNumber, opts ...MinSeverityProcessorOption) *MinSeverityProcessor {
+	cfg := &minSeverityConfig{min: min}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	p := &MinSeverityProcessor{next: next}
+	p.cfg.Store(cfg)
+	return p
+}
+
+// SetMinSeverity sets the minimum severity of the log records of the
+// instrumentation scopes that match no pattern. It is safe to call
+// concurrently with the processing of log records.
+func (p *MinSeverityProcessor) SetMinSeverity(min logs.SeverityNumber) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	cfg := p.cfg.Load().clone()
+	cfg.min = min
+	p.cfg.Store(cfg)
+}
+
+// SetScopeMinSeverity sets the minimum severity of the instrumentation scopes
+// matching pattern, see WithScopeMinSeverity. It is safe to call concurrently
+// with the processing of log records.
+func (p *MinSeverityProcessor) SetScopeMinSeverity(pattern string, min logs.SeverityNumber) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	cfg := p.cfg.Load().clone()
+	cfg.setScope(pattern, min)
+	p.cfg.Store(cfg)
+}
+
+// RemoveScopeMinSeverity removes the minimum severity set for pattern. It is
+// safe to call concurrently with the processing of log records.
+func (p *MinSeverityProcessor) RemoveScopeMinSeverity(pattern string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	cfg := p.cfg.Load().clone()
+	for i, r := range cfg.rules {
+		if r.pattern == pattern {
+			cfg.rules = append(cfg.rules[:i:i], cfg.rules[i+1:]...)
+			break
+		}
+	}
+	p.cfg.Store(cfg)
+}
+
+// MinSeverity returns the minimum severity of the log records of the
+// instrumentation scope name.
+func (p *MinSeverityProcessor) MinSeverity(name string) logs.SeverityNumber {
+	return p.cfg.Load().minSeverity(name)
+}
+
+// OnEmit passes rol on if its severity is high enough.
+func (p *MinSeverityProcessor) OnEmit(rol ReadableLogRecord) {
+	p.OnEmitContext(context.Background(), rol)
+}
+
+// OnEmitContext passes rol on within ctx if its severity is high enough.
+func (p *MinSeverityProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	if sn := rol.SeverityNumber(); sn != nil && *sn != logs.UNSPECIFIED {
+		var name string
+		if is := rol.InstrumentationScope(); is != nil {
+			name = is.Name
+		}
+		if *sn < p.cfg.Load().minSeverity(name) {
+			return
+		}
+	}
+	onEmit(ctx, p.next, rol)
+}
+
+// Enabled reports whether a log record with param would be passed on.
+//
+// The instrumentation scope is not known here, so the lowest minimum severity
+// of all scopes applies.
+func (p *MinSeverityProcessor) Enabled(ctx context.Context, param logs.EnabledParameters) bool {
+	if param.Severity != logs.UNSPECIFIED && param.Severity < p.cfg.Load().lowest() {
+		return false
+	}
+	return enabled(ctx, p.next, param)
+}
+
+// Shutdown shuts the wrapped processor down.
+func (p *MinSeverityProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the wrapped processor.
+func (p *MinSeverityProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// minSeverityConfig is an immutable snapshot of the minimum severities of a
+// MinSeverityProcessor, apart from its cache.
+type minSeverityConfig struct {
+	min   logs.SeverityNumber
+	rules []scopeSeverityRule
+	// cache maps scope names onto their minimum severity.
+	cache sync.Map
+}
+
+type scopeSeverityRule struct {
+	pattern string
+	min     logs.SeverityNumber
+}
+
+func (c *minSeverityConfig) clone() *minSeverityConfig {
+	return &minSeverityConfig{
+		min:   c.min,
+		rules: append([]scopeSeverityRule(nil), c.rules...),
+	}
+}
+
+func (c *minSeverityConfig) setScope(pattern string, min logs.SeverityNumber) {
+	for i, r := range c.rules {
+		if r.pattern == pattern {
+			c.rules[i].min = min
+			return
+		}
+	}
+	c.rules = append(c.rules, scopeSeverityRule{pattern: pattern, min: min})
+}
+
+func (c *minSeverityConfig) minSeverity(name string) logs.SeverityNumber {
+	if len(c.rules) == 0 {
+		return c.min
+	}
+	if min, ok := c.cache.Load(name); ok {
+		return min.(logs.SeverityNumber)
+	}
+
+	min, specificity := c.min, -1
+	for _, r := range c.rules {
+		if s := literalLength(r.pattern); s > specificity && globMatch(r.pattern, name) {
+			min, specificity = r.min, s
+		}
+	}
+	c.cache.Store(name, min)
+	return min
+}
+
+// lowest returns the lowest minimum severity of all scopes.
+func (c *minSeverityConfig) lowest() logs.SeverityNumber {
+	min := c.min
+	for _, r := range c.rules {
+		if r.min < min {
+			min = r.min
+		}
+	}
+	return min
+}
+
+// literalLength returns the number of characters of pattern that are not
+// wildcards.
+func literalLength(pattern string) int {
+	return len(pattern) - strings.Count(pattern, "*") - strings.Count(pattern, "?")
+}
+
+// globMatch reports whether name matches pattern, where '*' matches any
+// sequence of characters and '?' matches any single character.
+func globMatch(pattern, name string) bool {
+	// Position to resume from after the last '*', if any.
+	starP, starN := -1, 0
+	p, n := 0, 0
+	for n < len(name) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			starP, starN = p, n
+			p++
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == name[n]):
+			p++
+			n++
+		case starP >= 0:
+			starN++
+			p, n = starP+1, starN
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
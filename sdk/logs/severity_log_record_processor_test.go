@@ -0,0 +1,109 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func emitSeverity(logger logs.Logger, sn logs.SeverityNumber) {
+	logger.Emit(logs.NewLogRecord(logs.LogRecordConfig{SeverityNumber: &sn}))
+}
+
+func TestMinSeverityProcessor(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewMinSeverityProcessor(next, logs.WARN,
+		WithScopeMinSeverity("github.com/acme/*", logs.DEBUG),
+		WithScopeMinSeverity("github.com/acme/noisy", logs.ERROR),
+	)
+	provider := NewLoggerProvider(WithLogRecordProcessor(processor))
+
+	app := provider.Logger("app")
+	emitSeverity(app, logs.INFO)
+	emitSeverity(app, logs.WARN)
+	app.Emit(logs.NewLogRecord(logs.LogRecordConfig{EventName: "event"}))
+
+	acme := provider.Logger("github.com/acme/db/sql")
+	emitSeverity(acme, logs.DEBUG)
+	emitSeverity(acme, logs.TRACE4)
+
+	noisy := provider.Logger("github.com/acme/noisy")
+	emitSeverity(noisy, logs.WARN4)
+	emitSeverity(noisy, logs.ERROR)
+
+	require.Len(t, next.records, 4)
+	assert.Equal(t, logs.WARN, *next.records[0].SeverityNumber())
+	assert.Equal(t, "event", next.records[1].EventName())
+	assert.Equal(t, logs.DEBUG, *next.records[2].SeverityNumber())
+	assert.Equal(t, logs.ERROR, *next.records[3].SeverityNumber())
+}
+
+func TestMinSeverityProcessorSetters(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewMinSeverityProcessor(next, logs.INFO)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("db")
+
+	emitSeverity(logger, logs.INFO)
+	processor.SetMinSeverity(logs.ERROR)
+	emitSeverity(logger, logs.INFO)
+	processor.SetScopeMinSeverity("d?", logs.TRACE)
+	emitSeverity(logger, logs.DEBUG)
+	assert.Equal(t, logs.TRACE, processor.MinSeverity("db"))
+	processor.RemoveScopeMinSeverity("d?")
+	emitSeverity(logger, logs.DEBUG)
+
+	require.Len(t, next.records, 2)
+	assert.Equal(t, logs.INFO, *next.records[0].SeverityNumber())
+	assert.Equal(t, logs.DEBUG, *next.records[1].SeverityNumber())
+	assert.Equal(t, logs.ERROR, processor.MinSeverity("db"))
+}
+
+func TestMinSeverityProcessorEnabled(t *testing.T) {
+	processor := NewMinSeverityProcessor(&severityFilterProcessor{min: logs.WARN}, logs.INFO,
+		WithScopeMinSeverity("verbose", logs.DEBUG))
+	ctx := context.Background()
+
+	assert.False(t, processor.Enabled(ctx, logs.EnabledParameters{Severity: logs.TRACE}))
+	// The next processor filters below WARN.
+	assert.False(t, processor.Enabled(ctx, logs.EnabledParameters{Severity: logs.DEBUG}))
+	assert.True(t, processor.Enabled(ctx, logs.EnabledParameters{Severity: logs.WARN}))
+}
+
+func TestGlobMatch(t *testing.T) {
+	for _, test := range []struct {
+		pattern, name string
+		match         bool
+	}{
+		{"*", "anything/at/all", true},
+		{"github.com/acme/*", "github.com/acme/db/sql", true},
+		{"github.com/acme/*", "github.com/other", false},
+		{"*/sql", "github.com/acme/db/sql", true},
+		{"a?c", "abc", true},
+		{"a?c", "ac", false},
+		{"a*b*c", "aXXbYYc", true},
+		{"a*b*c", "aXXbYY", false},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+		{"", "", true},
+	} {
+		assert.Equal(t, test.match, globMatch(test.pattern, test.name), "%s %s", test.pattern, test.name)
+	}
+}
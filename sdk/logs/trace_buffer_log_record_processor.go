@@ -0,0 +1,442 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"container/list"
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults for TraceBufferProcessorOptions.
+const (
+	DefaultTraceBufferTTL             = 30 * time.Second
+	DefaultMaxBufferedPerTrace        = 256
+	DefaultMaxBufferedRecords         = 8192
+	DefaultTraceBufferMaxSeverity     = logs.INFO4
+	DefaultTraceBufferTriggerSeverity = logs.ERROR
+)
+
+// TraceBufferProcessorOption configures a TraceBufferProcessor.
+type TraceBufferProcessorOption func(o *TraceBufferProcessorOptions)
+
+// TraceBufferProcessorOptions is configuration settings for a
+// TraceBufferProcessor.
+type TraceBufferProcessorOptions struct {
+	// MaxSeverity is the highest severity of the log records that are
+	// buffered. The default is INFO4, so TRACE, DEBUG and INFO log records are
+	// buffered.
+	MaxSeverity logs.SeverityNumber
+
+	// TriggerSeverity is the lowest severity of the log records that flush
+	// the buffer of their trace. The default is ERROR.
+	TriggerSeverity logs.SeverityNumber
+
+	// TTL is how long log records of a trace are buffered before they are
+	// dropped, and how long log records of a trace whose buffer was flushed
+	// are passed on without buffering. The default is 30 seconds.
+	TTL time.Duration
+
+	// MaxPerTrace is the maximum number of buffered log records of a trace.
+	// The oldest ones are dropped when it is exceeded. The default is 256.
+	MaxPerTrace int
+
+	// MaxRecords is the maximum number of buffered log records of all traces.
+	// The buffers of the oldest traces are dropped when it is exceeded. The
+	// default is 8192.
+	MaxRecords int
+}
+
+// WithTraceBufferMaxSeverity sets the highest severity of the log records that
+// are buffered.
+func WithTraceBufferMaxSeverity(severity logs.SeverityNumber) TraceBufferProcessorOption {
+	return func(o *TraceBufferProcessorOptions) {
+		o.MaxSeverity = severity
+	}
+}
+
+// WithTraceBufferTriggerSeverity sets the lowest severity of the log records
+// that flush the buffer of their trace.
+func WithTraceBufferTriggerSeverity(severity logs.SeverityNumber) TraceBufferProcessorOption {
+	return func(o *TraceBufferProcessorOptions) {
+		o.TriggerSeverity = severity
+	}
+}
+
+// WithTraceBufferTTL sets how long log records of a trace are buffered.
+func WithTraceBufferTTL(ttl time.Duration) TraceBufferProcessorOption {
+	return func(o *TraceBufferProcessorOptions) {
+		o.TTL = ttl
+	}
+}
+
+// WithMaxBufferedPerTrace sets the maximum number of buffered log records of a
+// trace.
+func WithMaxBufferedPerTrace(size int) TraceBufferProcessorOption {
+	return func(o *TraceBufferProcessorOptions) {
+		o.MaxPerTrace = size
+	}
+}
+
+// WithMaxBufferedRecords sets the maximum number of buffered log records of
+// all traces.
+func WithMaxBufferedRecords(size int) TraceBufferProcessorOption {
+	return func(o *TraceBufferProcessorOptions) {
+		o.MaxRecords = size
+	}
+}
+
+// TraceBufferProcessor is a LogRecordProcessor that holds back low severity
+// log records of a trace until a log record of that trace shows that
+// something went wrong.
+//
+// Log records of a trace up to MaxSeverity are buffered per trace. When a log
+// record of at least TriggerSeverity arrives for the trace, the buffered log
+// records are passed on to the next processor in the order they were emitted,
+// followed by the triggering log record, and later log records of the trace
+// are passed on directly until the TTL runs out. Buffered log records are
+// dropped when the TTL runs out or the buffer limits are exceeded.
+//
+// Log records without a trace, without a severity or between MaxSeverity and
+// TriggerSeverity are passed on directly.
+//
+// Only the span context and the baggage of the context of a buffered log
+// record are kept, flushed log records are passed on within a new context
+// holding them. The buffers whose TTL ran out are dropped every half TTL and
+// on ForceFlush. Shutdown drops the buffered log records, as their traces did
+// not fail, and counts them in Dropped.
+//
+// SpanProcessor returns a span processor that makes the end of a trace
+// trigger the decision instead of waiting for the TTL.
+type TraceBufferProcessor struct {
+	next LogRecordProcessor
+	o    TraceBufferProcessorOptions
+	now  func() time.Time
+
+	mu      sync.Mutex
+	buffers map[trace.TraceID]*traceBuffer
+	// order holds the buffers from the oldest to the newest.
+	order *list.List
+	// flushed holds the traces whose buffer was flushed with the time until
+	// their log records are passed on directly.
+	flushed   map[trace.TraceID]time.Time
+	buffered  int
+	lastSweep time.Time
+
+	dropped atomic.Uint64
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	stopped  chan struct{}
+}
+
+var _ ContextLogRecordProcessor = (*TraceBufferProcessor)(nil)
+
+type traceBuffer struct {
+	id      trace.TraceID
+	created time.Time
+	records []bufferedLogRecord
+	elem    *list.Element
+}
+
+// bufferedLogRecord is a buffered log record and the parts of its context
+// that are passed on with it.
+type bufferedLogRecord struct {
+	spanContext trace.SpanContext
+	baggage     baggage.Baggage
+	rol         ReadableLogRecord
+}
+
+// context returns the context the log record is passed on within.
+func (r bufferedLogRecord) context() context.Context {
+	ctx := baggage.ContextWithBaggage(context.Background(), r.baggage)
+	return trace.ContextWithSpanContext(ctx, r.spanContext)
+}
+
+// NewTraceBufferProcessor returns a TraceBufferProcessor that passes log
+// records on to next.
+func NewTraceBufferProcessor(next LogRecordProcessor, options ...TraceBufferProcessorOption) *TraceBufferProcessor {
+	o := TraceBufferProcessorOptions{
+		MaxSeverity:     DefaultTraceBufferMaxSeverity,
+		TriggerSeverity: DefaultTraceBufferTriggerSeverity,
+		TTL:             DefaultTraceBufferTTL,
+		MaxPerTrace:     DefaultMaxBufferedPerTrace,
+		MaxRecords:      DefaultMaxBufferedRecords,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	p := &TraceBufferProcessor{
+		next:    next,
+		o:       o,
+		now:     time.Now,
+		buffers: make(map[trace.TraceID]*traceBuffer),
+		order:   list.New(),
+		flushed: make(map[trace.TraceID]time.Time),
+		stopCh:  make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if o.TTL > 0 {
+		go p.sweepPeriodically()
+	} else {
+		close(p.stopped)
+	}
+	return p
+}
+
+// OnEmit buffers rol or passes it on.
+func (p *TraceBufferProcessor) OnEmit(rol ReadableLogRecord) {
+	p.OnEmitContext(context.Background(), rol)
+}
+
+// OnEmitContext buffers rol or passes it on within ctx.
+func (p *TraceBufferProcessor) OnEmitContext(ctx context.Context, rol ReadableLogRecord) {
+	sn := rol.SeverityNumber()
+	if rol.TraceId() == nil || !rol.TraceId().IsValid() || sn == nil || *sn == logs.UNSPECIFIED {
+		onEmit(ctx, p.next, rol)
+		return
+	}
+	id := *rol.TraceId()
+
+	var flush []bufferedLogRecord
+	p.mu.Lock()
+	now := p.now()
+	p.sweep(now)
+	switch {
+	case *sn >= p.o.TriggerSeverity:
+		flush = p.remove(id)
+		p.flushed[id] = now.Add(p.o.TTL)
+	case *sn > p.o.MaxSeverity:
+	case p.isFlushed(id, now):
+	default:
+		p.add(ctx, id, rol, now)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	for _, r := range flush {
+		onEmit(r.context(), p.next, r.rol)
+	}
+	onEmit(ctx, p.next, rol)
+}
+
+// Flush passes the buffered log records of the trace on to the next
+// processor, as if a log record of the trace reached TriggerSeverity.
+func (p *TraceBufferProcessor) Flush(id trace.TraceID) {
+	p.mu.Lock()
+	flush := p.remove(id)
+	p.flushed[id] = p.now().Add(p.o.TTL)
+	p.mu.Unlock()
+
+	for _, r := range flush {
+		onEmit(r.context(), p.next, r.rol)
+	}
+}
+
+// Discard drops the buffered log records of the trace.
+func (p *TraceBufferProcessor) Discard(id trace.TraceID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dropped.Add(uint64(len(p.remove(id))))
+}
+
+// Buffered returns the number of buffered log records.
+func (p *TraceBufferProcessor) Buffered() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.buffered
+}
+
+// Dropped returns the number of buffered log records that were dropped.
+func (p *TraceBufferProcessor) Dropped() uint64 {
+	return p.dropped.Load()
+}
+
+// Shutdown stops dropping expired buffers, drops the buffered log records
+// without passing them on and shuts the wrapped processor down.
+func (p *TraceBufferProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stopCh) })
+	select {
+	case <-p.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.mu.Lock()
+	p.dropped.Add(uint64(p.buffered))
+	p.buffers = make(map[trace.TraceID]*traceBuffer)
+	p.order.Init()
+	p.flushed = make(map[trace.TraceID]time.Time)
+	p.buffered = 0
+	p.mu.Unlock()
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush drops the buffers whose TTL ran out and flushes the wrapped
+// processor. The other buffered log records are kept, their trace has not
+// failed yet.
+func (p *TraceBufferProcessor) ForceFlush(ctx context.Context) error {
+	p.mu.Lock()
+	now := p.now()
+	p.lastSweep = time.Time{}
+	p.sweep(now)
+	p.mu.Unlock()
+	return p.next.ForceFlush(ctx)
+}
+
+// SpanProcessor returns a trace SDK span processor that decides about the
+// buffered log records of a trace when its local root span ends. They are
+// flushed if the span has an error status and dropped otherwise.
+func (p *TraceBufferProcessor) SpanProcessor() sdktrace.SpanProcessor {
+	return traceBufferSpanProcessor{p: p}
+}
+
+// add buffers rol. It must be called with mu held.
+func (p *TraceBufferProcessor) add(ctx context.Context, id trace.TraceID, rol ReadableLogRecord, now time.Time) {
+	// The record must not change after OnEmit returns.
+	if elr, ok := rol.(*exportableLogRecord); ok {
+		rol = elr.clone()
+	}
+
+	b, ok := p.buffers[id]
+	if !ok {
+		b = &traceBuffer{id: id, created: now}
+		b.elem = p.order.PushBack(b)
+		p.buffers[id] = b
+	}
+	if len(b.records) >= p.o.MaxPerTrace {
+		n := len(b.records) - p.o.MaxPerTrace + 1
+		// The records are copied so the dropped ones can be collected.
+		records := make([]bufferedLogRecord, len(b.records)-n, p.o.MaxPerTrace)
+		copy(records, b.records[n:])
+		b.records = records
+		p.buffered -= n
+		p.dropped.Add(uint64(n))
+	}
+	if p.o.MaxPerTrace > 0 {
+		b.records = append(b.records, bufferedLogRecord{
+			spanContext: trace.SpanContextFromContext(ctx),
+			baggage:     baggage.FromContext(ctx),
+			rol:         rol,
+		})
+		p.buffered++
+	} else {
+		p.dropped.Add(1)
+	}
+
+	for p.buffered > p.o.MaxRecords && p.order.Len() > 0 {
+		oldest := p.order.Front().Value.(*traceBuffer)
+		p.dropped.Add(uint64(len(p.remove(oldest.id))))
+	}
+}
+
+// remove removes and returns the buffered log records of the trace. It must
+// be called with mu held.
+func (p *TraceBufferProcessor) remove(id trace.TraceID) []bufferedLogRecord {
+	b, ok := p.buffers[id]
+	if !ok {
+		return nil
+	}
+	delete(p.buffers, id)
+	p.order.Remove(b.elem)
+	p.buffered -= len(b.records)
+	return b.records
+}
+
+// isFlushed reports whether the log records of the trace are passed on
+// directly. It must be called with mu held.
+func (p *TraceBufferProcessor) isFlushed(id trace.TraceID, now time.Time) bool {
+	until, ok := p.flushed[id]
+	return ok && now.Before(until)
+}
+
+// sweep drops the buffers whose TTL ran out. It must be called with mu held.
+func (p *TraceBufferProcessor) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < p.o.TTL/2 {
+		return
+	}
+	p.lastSweep = now
+
+	for p.order.Len() > 0 {
+		oldest := p.order.Front().Value.(*traceBuffer)
+		if now.Sub(oldest.created) < p.o.TTL {
+			break
+		}
+		p.dropped.Add(uint64(len(p.remove(oldest.id))))
+	}
+	for id, until := range p.flushed {
+		if !now.Before(until) {
+			delete(p.flushed, id)
+		}
+	}
+}
+
+// sweepPeriodically drops the buffers whose TTL ran out every half TTL until
+// the processor is shut down.
+func (p *TraceBufferProcessor) sweepPeriodically() {
+	defer close(p.stopped)
+	interval := p.o.TTL / 2
+	if interval <= 0 {
+		interval = p.o.TTL
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.mu.Lock()
+			p.sweep(p.now())
+			p.mu.Unlock()
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+// traceBufferSpanProcessor ends the buffering of a trace when its local root
+// span ends.
+type traceBufferSpanProcessor struct {
+	p *TraceBufferProcessor
+}
+
+var _ sdktrace.SpanProcessor = traceBufferSpanProcessor{}
+
+func (sp traceBufferSpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (sp traceBufferSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.Parent().IsValid() && !s.Parent().IsRemote() {
+		return
+	}
+	id := s.SpanContext().TraceID()
+	if s.Status().Code == codes.Error {
+		sp.p.Flush(id)
+		return
+	}
+	sp.p.Discard(id)
+}
+
+func (sp traceBufferSpanProcessor) Shutdown(context.Context) error { return nil }
+
+func (sp traceBufferSpanProcessor) ForceFlush(context.Context) error { return nil }
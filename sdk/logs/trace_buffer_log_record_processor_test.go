@@ -0,0 +1,210 @@
+/*
+Copyright Agoda Services Co.,Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"context"
+	"github.com/agoda-com/opentelemetry-logs-go/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+	"time"
+)
+
+func emitInTrace(logger logs.Logger, ctx context.Context, sn logs.SeverityNumber, body string) {
+	logger.EmitContext(ctx, logs.NewLogRecord(logs.LogRecordConfig{
+		SeverityNumber: &sn,
+		BodyValue:      logs.StringValue(body),
+	}))
+}
+
+func bodies(records []ReadableLogRecord) []string {
+	var s []string
+	for _, r := range records {
+		s = append(s, r.BodyValue().AsString())
+	}
+	return s
+}
+
+func TestTraceBufferProcessor(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewTraceBufferProcessor(next)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	failing, passing := traceContext(1, trace.FlagsSampled), traceContext(2, trace.FlagsSampled)
+
+	emitInTrace(logger, failing, logs.DEBUG, "debug")
+	emitInTrace(logger, passing, logs.INFO, "other")
+	emitInTrace(logger, failing, logs.INFO, "info")
+	emitInTrace(logger, failing, logs.WARN, "warn")
+	emitInTrace(logger, context.Background(), logs.DEBUG, "no trace")
+	assert.Equal(t, []string{"warn", "no trace"}, bodies(next.records))
+	assert.Equal(t, 3, processor.Buffered())
+
+	emitInTrace(logger, failing, logs.ERROR, "error")
+	emitInTrace(logger, failing, logs.DEBUG, "after")
+	assert.Equal(t, []string{"warn", "no trace", "debug", "info", "error", "after"}, bodies(next.records))
+	assert.Equal(t, 1, processor.Buffered())
+
+	processor.Discard(trace.SpanContextFromContext(passing).TraceID())
+	assert.Equal(t, 0, processor.Buffered())
+	assert.Equal(t, uint64(1), processor.Dropped())
+}
+
+func TestTraceBufferProcessorTTL(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewTraceBufferProcessor(next, WithTraceBufferTTL(time.Minute))
+	now := time.Unix(0, 0)
+	processor.now = func() time.Time { return now }
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	ctx := traceContext(1, trace.FlagsSampled)
+
+	emitInTrace(logger, ctx, logs.DEBUG, "expired")
+	now = now.Add(time.Minute)
+	emitInTrace(logger, ctx, logs.DEBUG, "kept")
+	emitInTrace(logger, ctx, logs.ERROR, "error")
+	assert.Equal(t, []string{"kept", "error"}, bodies(next.records))
+	assert.Equal(t, uint64(1), processor.Dropped())
+
+	// Once the TTL runs out, log records of the trace are buffered again.
+	now = now.Add(time.Minute)
+	emitInTrace(logger, ctx, logs.DEBUG, "buffered")
+	assert.Len(t, next.records, 2)
+	assert.Equal(t, 1, processor.Buffered())
+}
+
+func TestTraceBufferProcessorLimits(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewTraceBufferProcessor(next, WithMaxBufferedPerTrace(2), WithMaxBufferedRecords(3))
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	first, second := traceContext(1, trace.FlagsSampled), traceContext(2, trace.FlagsSampled)
+
+	emitInTrace(logger, first, logs.DEBUG, "1")
+	emitInTrace(logger, first, logs.DEBUG, "2")
+	emitInTrace(logger, first, logs.DEBUG, "3")
+	assert.Equal(t, 2, processor.Buffered())
+
+	// The oldest trace is dropped when all buffers are full.
+	emitInTrace(logger, second, logs.DEBUG, "4")
+	emitInTrace(logger, second, logs.DEBUG, "5")
+	assert.Equal(t, 2, processor.Buffered())
+	assert.Equal(t, uint64(3), processor.Dropped())
+
+	emitInTrace(logger, first, logs.ERROR, "error")
+	emitInTrace(logger, second, logs.ERROR, "error")
+	assert.Equal(t, []string{"error", "4", "5", "error"}, bodies(next.records))
+}
+
+func TestTraceBufferProcessorKeepsRecords(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewTraceBufferProcessor(next)
+	modifier := funcProcessor{onEmit: func(rol ReadableLogRecord) {
+		rol.(ReadWriteLogRecord).SetBodyValue(logs.StringValue("modified"))
+	}}
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor), WithLogRecordProcessor(modifier)).Logger("test")
+	ctx := traceContext(1, trace.FlagsSampled)
+
+	emitInTrace(logger, ctx, logs.DEBUG, "debug")
+	emitInTrace(logger, ctx, logs.ERROR, "error")
+	require.Len(t, next.records, 2)
+	assert.Equal(t, "debug", next.records[0].BodyValue().AsString())
+}
+
+func TestTraceBufferProcessorSpanProcessor(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewTraceBufferProcessor(next)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor.SpanProcessor())).Tracer("test")
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	ctx, child := tracer.Start(ctx, "child")
+	emitInTrace(logger, ctx, logs.DEBUG, "failed")
+	child.SetStatus(codes.Error, "child failed")
+	child.End()
+	assert.Equal(t, 1, processor.Buffered())
+	root.SetStatus(codes.Error, "failed")
+	root.End()
+
+	ctx, root = tracer.Start(context.Background(), "root")
+	emitInTrace(logger, ctx, logs.DEBUG, "succeeded")
+	root.End()
+
+	assert.Equal(t, []string{"failed"}, bodies(next.records))
+	assert.Equal(t, 0, processor.Buffered())
+}
+
+func TestTraceBufferProcessorShutdown(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewTraceBufferProcessor(next)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	emitInTrace(logger, traceContext(1, trace.FlagsSampled), logs.DEBUG, "debug")
+	require.NoError(t, processor.ForceFlush(context.Background()))
+	assert.Equal(t, 1, processor.Buffered())
+	require.NoError(t, processor.Shutdown(context.Background()))
+	assert.Equal(t, 0, processor.Buffered())
+	assert.Empty(t, next.records)
+}
+
+func TestTraceBufferProcessorContext(t *testing.T) {
+	type ctxKey struct{}
+	next := &testProcessor{}
+	processor := NewTraceBufferProcessor(next)
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	member, err := baggage.NewMember("tenant.id", "t-42")
+	require.NoError(t, err)
+	b, err := baggage.New(member)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(traceContext(1, trace.FlagsSampled), b)
+	ctx = context.WithValue(ctx, ctxKey{}, "request scoped")
+
+	emitInTrace(logger, ctx, logs.DEBUG, "debug")
+	emitInTrace(logger, ctx, logs.ERROR, "error")
+
+	require.Len(t, next.ctxs, 2)
+	flushed := next.ctxs[0]
+	assert.Equal(t, trace.SpanContextFromContext(ctx), trace.SpanContextFromContext(flushed))
+	assert.Equal(t, "t-42", baggage.FromContext(flushed).Member("tenant.id").Value())
+	assert.Nil(t, flushed.Value(ctxKey{}))
+}
+
+func TestTraceBufferProcessorSweep(t *testing.T) {
+	next := &testProcessor{}
+	processor := NewTraceBufferProcessor(next, WithTraceBufferTTL(time.Hour))
+	now := time.Unix(0, 0)
+	processor.now = func() time.Time { return now }
+	logger := NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+
+	emitInTrace(logger, traceContext(1, trace.FlagsSampled), logs.DEBUG, "expired")
+	now = now.Add(time.Hour)
+	require.NoError(t, processor.ForceFlush(context.Background()))
+	assert.Equal(t, 0, processor.Buffered())
+	assert.Equal(t, uint64(1), processor.Dropped())
+	require.NoError(t, processor.Shutdown(context.Background()))
+
+	// Expired buffers of an idle processor are dropped too.
+	processor = NewTraceBufferProcessor(next, WithTraceBufferTTL(20*time.Millisecond))
+	logger = NewLoggerProvider(WithLogRecordProcessor(processor)).Logger("test")
+	emitInTrace(logger, traceContext(1, trace.FlagsSampled), logs.DEBUG, "idle")
+	assert.Eventually(t, func() bool { return processor.Buffered() == 0 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, processor.Shutdown(context.Background()))
+	assert.Empty(t, next.records)
+}